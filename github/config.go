@@ -27,6 +27,15 @@ type Config struct {
 	RetryableErrors  map[int]bool
 	MaxRetries       int
 	ParallelRequests bool
+	RequestsPerHour  int
+
+	ValidateEnvironmentReviewers bool
+	WarnOnDisabledActions        bool
+	MaxWaitTimer                 int
+	EnvironmentDefaults          *EnvironmentDefaults
+	UserAgentSuffix              string
+	APIVersion                   string
+	DryRun                       bool
 }
 
 type Owner struct {
@@ -36,6 +45,24 @@ type Owner struct {
 	v4client       *githubv4.Client
 	StopContext    context.Context
 	IsOrganization bool
+
+	validateEnvironmentReviewers bool
+	warnOnDisabledActions        bool
+	maxWaitTimer                 int
+	environmentDefaults          *EnvironmentDefaults
+	dryRun                       bool
+
+	environmentETags      environmentETagCache
+	protectedBranches     protectedBranchCache
+	environmentPublicKeys publicKeyCache
+}
+
+// FlushAllCaches drops every in-memory cache held by the owner, forcing
+// subsequent reads to go back to the GitHub API.
+func (o *Owner) FlushAllCaches() {
+	o.environmentETags.clear()
+	o.protectedBranches.clear()
+	o.environmentPublicKeys.clear()
 }
 
 const (
@@ -56,9 +83,9 @@ var (
 	GHECAPIHostMatch = regexp.MustCompile(`^api\.[a-zA-Z0-9-]+\.ghe\.com$`)
 )
 
-func RateLimitedHTTPClient(client *http.Client, writeDelay, readDelay, retryDelay time.Duration, parallelRequests bool, retryableErrors map[int]bool, maxRetries int) *http.Client {
+func RateLimitedHTTPClient(client *http.Client, writeDelay, readDelay, retryDelay time.Duration, parallelRequests bool, retryableErrors map[int]bool, maxRetries, requestsPerHour int) *http.Client {
 	client.Transport = NewEtagTransport(client.Transport)
-	client.Transport = NewRateLimitTransport(client.Transport, WithWriteDelay(writeDelay), WithReadDelay(readDelay), WithParallelRequests(parallelRequests))
+	client.Transport = NewRateLimitTransport(client.Transport, WithWriteDelay(writeDelay), WithReadDelay(readDelay), WithParallelRequests(parallelRequests), WithRequestsPerHour(requestsPerHour))
 	client.Transport = logging.NewLoggingHTTPTransport(client.Transport)
 	client.Transport = newPreviewHeaderInjectorTransport(map[string]string{
 		// TODO: remove when Stone Crop preview is moved to general availability in the GraphQL API
@@ -79,7 +106,7 @@ func (c *Config) AuthenticatedHTTPClient() *http.Client {
 	)
 	client := oauth2.NewClient(ctx, ts)
 
-	return RateLimitedHTTPClient(client, c.WriteDelay, c.ReadDelay, c.RetryDelay, c.ParallelRequests, c.RetryableErrors, c.MaxRetries)
+	return RateLimitedHTTPClient(client, c.WriteDelay, c.ReadDelay, c.RetryDelay, c.ParallelRequests, c.RetryableErrors, c.MaxRetries, c.RequestsPerHour)
 }
 
 func (c *Config) Anonymous() bool {
@@ -88,7 +115,7 @@ func (c *Config) Anonymous() bool {
 
 func (c *Config) AnonymousHTTPClient() *http.Client {
 	client := &http.Client{Transport: &http.Transport{}}
-	return RateLimitedHTTPClient(client, c.WriteDelay, c.ReadDelay, c.RetryDelay, c.ParallelRequests, c.RetryableErrors, c.MaxRetries)
+	return RateLimitedHTTPClient(client, c.WriteDelay, c.ReadDelay, c.RetryDelay, c.ParallelRequests, c.RetryableErrors, c.MaxRetries, c.RequestsPerHour)
 }
 
 func (c *Config) NewGraphQLClient(client *http.Client) (*githubv4.Client, error) {
@@ -150,7 +177,21 @@ func (c *Config) Meta() (any, error) {
 		client = c.AuthenticatedHTTPClient()
 	}
 
-	v3client, err := c.NewRESTClient(client)
+	if c.UserAgentSuffix != "" {
+		client.Transport = newUserAgentSuffixTransport(c.UserAgentSuffix, client.Transport)
+	}
+
+	// Only the REST API is versioned, so the GraphQL client keeps using the
+	// unmodified HTTP client.
+	restClient := client
+	if c.APIVersion != "" {
+		restClient = &http.Client{
+			Transport: newAPIVersionTransport(c.APIVersion, client.Transport),
+			Timeout:   client.Timeout,
+		}
+	}
+
+	v3client, err := c.NewRESTClient(restClient)
 	if err != nil {
 		return nil, err
 	}
@@ -164,6 +205,11 @@ func (c *Config) Meta() (any, error) {
 	owner.v4client = v4client
 	owner.v3client = v3client
 	owner.StopContext = context.Background()
+	owner.validateEnvironmentReviewers = c.ValidateEnvironmentReviewers
+	owner.warnOnDisabledActions = c.WarnOnDisabledActions
+	owner.maxWaitTimer = c.MaxWaitTimer
+	owner.environmentDefaults = c.EnvironmentDefaults
+	owner.dryRun = c.DryRun
 
 	_, err = c.ConfigureOwner(&owner)
 	if err != nil {
@@ -201,6 +247,57 @@ func (injector *previewHeaderInjectorTransport) RoundTrip(req *http.Request) (*h
 	return injector.rt.RoundTrip(req)
 }
 
+// userAgentSuffixTransport appends a suffix to the User-Agent of every
+// request, so that both the REST and GraphQL clients identify the caller.
+type userAgentSuffixTransport struct {
+	rt     http.RoundTripper
+	suffix string
+}
+
+func newUserAgentSuffixTransport(suffix string, rt http.RoundTripper) *userAgentSuffixTransport {
+	return &userAgentSuffixTransport{
+		rt:     rt,
+		suffix: suffix,
+	}
+}
+
+func (uat *userAgentSuffixTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	userAgent := uat.suffix
+	if ua := req.Header.Get("User-Agent"); ua != "" {
+		userAgent = ua + " " + uat.suffix
+	}
+
+	// RoundTrippers must not modify the original request.
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", userAgent)
+
+	return uat.rt.RoundTrip(req)
+}
+
+var apiVersionRegexp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// apiVersionTransport pins the X-GitHub-Api-Version header of every REST
+// request, overriding the default version sent by go-github.
+type apiVersionTransport struct {
+	rt      http.RoundTripper
+	version string
+}
+
+func newAPIVersionTransport(version string, rt http.RoundTripper) *apiVersionTransport {
+	return &apiVersionTransport{
+		rt:      rt,
+		version: version,
+	}
+}
+
+func (avt *apiVersionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the original request.
+	req = req.Clone(req.Context())
+	req.Header.Set("X-GitHub-Api-Version", avt.version)
+
+	return avt.rt.RoundTrip(req)
+}
+
 // getBaseURL returns a correctly configured base URL and a bool as to if this is GitHub Enterprise Server.
 func getBaseURL(s string) (*url.URL, bool, error) {
 	if len(s) == 0 {
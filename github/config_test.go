@@ -2,10 +2,16 @@ package github
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/shurcooL/githubv4"
 )
 
@@ -503,6 +509,205 @@ func TestPreviewHeaderInjectorTransport_RoundTrip(t *testing.T) {
 	}
 }
 
+func TestOwnerCachesAreIndependent(t *testing.T) {
+	first := &Owner{name: "first-org"}
+	second := &Owner{name: "second-org"}
+
+	first.environmentETags.set("first-org/repo/production", environmentETagEntry{etag: `"first"`})
+	second.environmentETags.set("second-org/repo/production", environmentETagEntry{etag: `"second"`})
+
+	if _, ok := second.environmentETags.get("first-org/repo/production"); ok {
+		t.Error("expected entries of the first owner not to be visible from the second owner")
+	}
+	if _, ok := first.environmentETags.get("second-org/repo/production"); ok {
+		t.Error("expected entries of the second owner not to be visible from the first owner")
+	}
+
+	first.FlushAllCaches()
+
+	if _, ok := first.environmentETags.get("first-org/repo/production"); ok {
+		t.Error("expected the first owner cache to be empty after a flush")
+	}
+	if entry, ok := second.environmentETags.get("second-org/repo/production"); !ok || entry.etag != `"second"` {
+		t.Error("expected flushing the first owner not to affect the second owner")
+	}
+}
+
+func TestConfigMetaAPIVersion(t *testing.T) {
+	var mu sync.Mutex
+	versions := make(map[string]string)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		versions[r.URL.Path] = r.Header.Get("X-GitHub-Api-Version")
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL + "/")
+	config := Config{
+		BaseURL:    baseURL,
+		APIVersion: "2026-03-10",
+	}
+
+	meta, err := config.Meta()
+	if err != nil {
+		t.Fatalf("failed to return meta without error: %s", err.Error())
+	}
+
+	if _, _, err := meta.(*Owner).v3client.Meta.Get(context.Background()); err != nil {
+		t.Fatalf("unexpected REST error: %s", err)
+	}
+
+	if got := versions["/meta"]; got != "2026-03-10" {
+		t.Errorf("expected X-GitHub-Api-Version to be 2026-03-10, got %q", got)
+	}
+}
+
+func TestConfigMetaUserAgentSuffix(t *testing.T) {
+	var mu sync.Mutex
+	userAgents := make(map[string]string)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		userAgents[r.URL.Path] = r.Header.Get("User-Agent")
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if r.URL.Path == "/graphql" {
+			_, _ = w.Write([]byte(`{"data": {"meta": {"gitIpAddresses": []}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL + "/")
+	config := Config{
+		BaseURL:         baseURL,
+		UserAgentSuffix: "pipeline/1.0",
+	}
+
+	meta, err := config.Meta()
+	if err != nil {
+		t.Fatalf("failed to return meta without error: %s", err.Error())
+	}
+	owner := meta.(*Owner)
+
+	ctx := context.Background()
+	if _, _, err := owner.v3client.Meta.Get(ctx); err != nil {
+		t.Fatalf("unexpected REST error: %s", err)
+	}
+
+	var query struct {
+		Meta struct {
+			GitIPAddresses githubv4.String
+		}
+	}
+	// The response shape is irrelevant here, only the request headers matter.
+	_ = owner.v4client.Query(ctx, &query, nil)
+
+	for _, path := range []string{"/meta", "/graphql"} {
+		if ua := userAgents[path]; !strings.HasSuffix(ua, " pipeline/1.0") && ua != "pipeline/1.0" {
+			t.Errorf("expected User-Agent of %s to end with the suffix, got %q", path, ua)
+		}
+	}
+
+	if ua := userAgents["/meta"]; !strings.HasPrefix(ua, "go-github/") {
+		t.Errorf("expected the REST User-Agent to keep the go-github prefix, got %q", ua)
+	}
+}
+
+func TestConfigureOwnerResolvesOrganizationOnce(t *testing.T) {
+	var mu sync.Mutex
+	orgRequests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case r.URL.Path == "/orgs/test-org":
+			mu.Lock()
+			orgRequests++
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"login": "test-org", "id": 1234, "node_id": "O_kgDO"}`))
+		case strings.HasPrefix(r.URL.Path, "/orgs/test-org/teams/"):
+			// Team slugs are team-<id>.
+			id := strings.TrimPrefix(parts[3], "team-")
+			fmt.Fprintf(w, `{"id": %s, "slug": "team-%s", "name": "team-%s"}`, id, id, id)
+		case strings.HasPrefix(r.URL.Path, "/organizations/1234/team/") && len(parts) > 4:
+			if r.Method != http.MethodGet {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			fmt.Fprintf(w, `{"name": %q, "role_name": "push", "permissions": {"push": true, "pull": true}}`, parts[6])
+		case strings.HasPrefix(r.URL.Path, "/organizations/1234/team/"):
+			fmt.Fprintf(w, `{"id": %s, "slug": "team-%s", "name": "team-%s"}`, parts[3], parts[3], parts[3])
+		case strings.HasPrefix(r.URL.Path, "/repos/test-org/"):
+			fmt.Fprintf(w, `{"id": 1, "name": %q}`, parts[2])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL + "/")
+	config := Config{
+		Token:   "token",
+		BaseURL: baseURL,
+		Owner:   "test-org",
+	}
+
+	meta, err := config.Meta()
+	if err != nil {
+		t.Fatalf("failed to return meta without error: %s", err.Error())
+	}
+	owner := meta.(*Owner)
+
+	if owner.id != 1234 || !owner.IsOrganization {
+		t.Fatalf("expected organization 1234 to be resolved, got id %d (organization: %t)", owner.id, owner.IsOrganization)
+	}
+
+	// Resolve team slugs and manage several teams and team repositories, all of
+	// which need the organization ID.
+	for i := 1; i <= 5; i++ {
+		slug := fmt.Sprintf("team-%d", i)
+		teamID, err := getTeamID(slug, owner)
+		if err != nil {
+			t.Fatalf("unexpected error resolving %s: %s", slug, err)
+		}
+		if teamID != int64(i) {
+			t.Fatalf("expected team ID %d for %s, got %d", i, slug, teamID)
+		}
+
+		team := resourceGithubTeam().TestResourceData()
+		team.SetId(strconv.FormatInt(teamID, 10))
+		if diags := resourceGithubTeamRead(context.Background(), team, owner); diags.HasError() {
+			t.Fatalf("unexpected error reading team %s: %v", slug, diags)
+		}
+
+		d := schema.TestResourceDataRaw(t, resourceGithubTeamRepository().Schema, map[string]any{
+			"team_id":    slug,
+			"repository": fmt.Sprintf("repo-%d", i),
+			"permission": "push",
+		})
+		if err := resourceGithubTeamRepositoryCreate(d, owner); err != nil {
+			t.Fatalf("unexpected error adding repo-%d to %s: %s", i, slug, err)
+		}
+		if err := resourceGithubTeamRepositoryRead(d, owner); err != nil {
+			t.Fatalf("unexpected error reading repo-%d of %s: %s", i, slug, err)
+		}
+		if err := resourceGithubTeamRepositoryDelete(d, owner); err != nil {
+			t.Fatalf("unexpected error removing repo-%d from %s: %s", i, slug, err)
+		}
+	}
+
+	if orgRequests != 1 {
+		t.Errorf("expected the organization to be resolved exactly once, got %d requests", orgRequests)
+	}
+}
+
 // mockRoundTripper is a mock implementation of http.RoundTripper for testing.
 type mockRoundTripper struct {
 	roundTripFunc func(*http.Request) (*http.Response, error)
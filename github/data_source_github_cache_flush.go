@@ -0,0 +1,36 @@
+package github
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubCacheFlush() *schema.Resource {
+	return &schema.Resource{
+		Description: "Flush every in-memory cache held by the provider when read.",
+		ReadContext: dataSourceGithubCacheFlushRead,
+
+		Schema: map[string]*schema.Schema{
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values used to order the flush after the resources they are taken from.",
+			},
+		},
+	}
+}
+
+func dataSourceGithubCacheFlushRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	owner := meta.(*Owner)
+
+	log.Printf("[INFO] Flushing all provider caches for %s", owner.name)
+	owner.FlushAllCaches()
+
+	d.SetId(owner.name)
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestGithubCacheFlushDataSource(t *testing.T) {
+	meta := &Owner{name: "test-owner"}
+	meta.environmentETags.set("test-owner/test-repo/production", environmentETagEntry{etag: `"abc123"`})
+	meta.protectedBranches.set("test-owner/test-repo", true)
+	meta.environmentPublicKeys.set("test-owner/test-repo/production", "key-id", "key")
+
+	d := schema.TestResourceDataRaw(t, dataSourceGithubCacheFlush().Schema, map[string]any{})
+
+	if diags := dataSourceGithubCacheFlushRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if _, ok := meta.environmentETags.get("test-owner/test-repo/production"); ok {
+		t.Error("expected environment ETag cache to be empty after a flush")
+	}
+	if _, ok := meta.protectedBranches.get("test-owner/test-repo"); ok {
+		t.Error("expected protected branch cache to be empty after a flush")
+	}
+	if _, _, ok := meta.environmentPublicKeys.get("test-owner/test-repo/production"); ok {
+		t.Error("expected environment public key cache to be empty after a flush")
+	}
+
+	if d.Id() != "test-owner" {
+		t.Errorf("expected id test-owner, got %q", d.Id())
+	}
+}
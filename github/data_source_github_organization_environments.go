@@ -0,0 +1,168 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v82/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubOrganizationEnvironments() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGithubOrganizationEnvironmentsRead,
+
+		Schema: map[string]*schema.Schema{
+			"ignore_archived_repos": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to skip the environments of archived repositories.",
+			},
+			"environments": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The environments of all repositories of the organization.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"repository": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the repository of the environment.",
+						},
+						"environment": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the environment.",
+						},
+						"wait_timer": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Amount of time to delay a job after the job is initially triggered.",
+						},
+						"reviewers_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of teams and users who may review jobs that reference the environment.",
+						},
+						"has_branch_policy": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the environment restricts the branches that can deploy to it.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGithubOrganizationEnvironmentsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	if err := checkOrganization(meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ignoreArchived := d.Get("ignore_archived_repos").(bool)
+
+	repoNames := make([]string, 0)
+	repoOpts := &github.RepositoryListByOrgOptions{
+		ListOptions: github.ListOptions{PerPage: maxPerPage},
+	}
+	for {
+		repos, resp, err := client.Repositories.ListByOrg(ctx, orgName, repoOpts)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, repo := range repos {
+			if ignoreArchived && repo.GetArchived() {
+				continue
+			}
+			repoNames = append(repoNames, repo.GetName())
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		repoOpts.Page = resp.NextPage
+	}
+
+	// Repositories are read one after the other, which keeps this data source
+	// from competing with the rest of the plan for the rate limit.
+	var diags diag.Diagnostics
+	results := make([]any, 0)
+	for _, repoName := range repoNames {
+		environments, err := listOrganizationRepositoryEnvironments(ctx, client, orgName, repoName)
+		if err != nil {
+			// A repository the token cannot read, or one deleted while the
+			// organization is listed, must not fail the whole read.
+			var ghErr *github.ErrorResponse
+			if errors.As(err, &ghErr) && (ghErr.Response.StatusCode == http.StatusForbidden || ghErr.Response.StatusCode == http.StatusNotFound) {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Skipping environments of repository %s", repoName),
+					Detail:   fmt.Sprintf("The environments of repository %s/%s could not be read: %s", orgName, repoName, err),
+				})
+				continue
+			}
+			return diag.FromErr(err)
+		}
+
+		results = append(results, environments...)
+	}
+
+	d.SetId(orgName)
+	if err := d.Set("environments", results); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func listOrganizationRepositoryEnvironments(ctx context.Context, client *github.Client, orgName, repoName string) ([]any, error) {
+	results := make([]any, 0)
+	envOpts := &github.EnvironmentListOptions{
+		ListOptions: github.ListOptions{PerPage: maxPerPage},
+	}
+	for {
+		environments, resp, err := client.Repositories.ListEnvironments(ctx, orgName, repoName, envOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, env := range environments.Environments {
+			results = append(results, flattenOrganizationEnvironment(repoName, env))
+		}
+
+		if resp.NextPage == 0 {
+			return results, nil
+		}
+		envOpts.Page = resp.NextPage
+	}
+}
+
+func flattenOrganizationEnvironment(repoName string, env *github.Environment) map[string]any {
+	waitTimer := 0
+	reviewersCount := 0
+	for _, pr := range env.ProtectionRules {
+		switch pr.GetType() {
+		case "wait_timer":
+			waitTimer = pr.GetWaitTimer()
+		case "required_reviewers":
+			reviewersCount = len(pr.Reviewers)
+		}
+	}
+
+	return map[string]any{
+		"repository":        repoName,
+		"environment":       env.GetName(),
+		"wait_timer":        waitTimer,
+		"reviewers_count":   reviewersCount,
+		"has_branch_policy": env.DeploymentBranchPolicy != nil,
+	}
+}
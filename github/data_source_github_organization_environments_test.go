@@ -0,0 +1,103 @@
+package github
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceGithubOrganizationEnvironmentsRead(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:  "/orgs/test-org/repos?per_page=100",
+			ResponseBody: `[{"name": "api"}, {"name": "web"}, {"name": "docs"}]`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri: "/repos/test-org/api/environments?per_page=100",
+			ResponseBody: `{"total_count": 2, "environments": [
+				{"name": "production", "deployment_branch_policy": {"protected_branches": true, "custom_branch_policies": false}, "protection_rules": [
+					{"type": "wait_timer", "wait_timer": 30},
+					{"type": "required_reviewers", "reviewers": [{"type": "User", "reviewer": {"id": 1}}, {"type": "Team", "reviewer": {"id": 2}}]}
+				]},
+				{"name": "staging"}
+			]}`,
+			StatusCode: 200,
+		},
+		{
+			ExpectedUri:  "/repos/test-org/web/environments?per_page=100",
+			ResponseBody: `{"total_count": 1, "environments": [{"name": "preview"}]}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:  "/repos/test-org/docs/environments?per_page=100",
+			ResponseBody: `{"total_count": 0, "environments": []}`,
+			StatusCode:   200,
+		},
+	})
+	meta.name = "test-org"
+	meta.IsOrganization = true
+
+	d := schema.TestResourceDataRaw(t, dataSourceGithubOrganizationEnvironments().Schema, map[string]any{})
+	if diags := dataSourceGithubOrganizationEnvironmentsRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	environments := d.Get("environments").([]any)
+	if len(environments) != 3 {
+		t.Fatalf("expected 3 environments, got %d", len(environments))
+	}
+
+	expected := []map[string]any{
+		{"repository": "api", "environment": "production", "wait_timer": 30, "reviewers_count": 2, "has_branch_policy": true},
+		{"repository": "api", "environment": "staging", "wait_timer": 0, "reviewers_count": 0, "has_branch_policy": false},
+		{"repository": "web", "environment": "preview", "wait_timer": 0, "reviewers_count": 0, "has_branch_policy": false},
+	}
+	for i, env := range environments {
+		for key, value := range expected[i] {
+			if got := env.(map[string]any)[key]; got != value {
+				t.Errorf("expected environments.%d.%s to be %v, got %v", i, key, value, got)
+			}
+		}
+	}
+}
+
+func TestDataSourceGithubOrganizationEnvironmentsSkipsInaccessibleRepositories(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:  "/orgs/test-org/repos?per_page=100",
+			ResponseBody: `[{"name": "deleted"}, {"name": "api"}]`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:  "/repos/test-org/deleted/environments?per_page=100",
+			ResponseBody: `{"message": "Not Found"}`,
+			StatusCode:   404,
+		},
+		{
+			ExpectedUri:  "/repos/test-org/api/environments?per_page=100",
+			ResponseBody: `{"total_count": 1, "environments": [{"name": "production"}]}`,
+			StatusCode:   200,
+		},
+	})
+	meta.name = "test-org"
+	meta.IsOrganization = true
+
+	d := schema.TestResourceDataRaw(t, dataSourceGithubOrganizationEnvironments().Schema, map[string]any{})
+	diags := dataSourceGithubOrganizationEnvironmentsRead(context.Background(), d, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Summary, "deleted") {
+		t.Fatalf("expected a single warning about repository deleted, got %v", diags)
+	}
+
+	environments := d.Get("environments").([]any)
+	if len(environments) != 1 || environments[0].(map[string]any)["repository"] != "api" {
+		t.Fatalf("expected only the environment of repository api, got %v", environments)
+	}
+}
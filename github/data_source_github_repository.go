@@ -329,6 +329,40 @@ func dataSourceGithubRepository() *schema.Resource {
 					},
 				},
 			},
+			"parent": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The repository this repository was directly forked from.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"owner": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"repository": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"source": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The root repository of the fork network this repository belongs to.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"owner": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"repository": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 			"node_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -341,6 +375,89 @@ func dataSourceGithubRepository() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"fork_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"stargazer_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"watcher_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"include_workflow_permissions": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to look up the default workflow permissions of the repository, which costs an extra request.",
+			},
+			"default_workflow_permissions": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The default permissions granted to the GITHUB_TOKEN when running workflows, either 'read' or 'write'.",
+			},
+			"can_approve_pull_request_reviews": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether GitHub Actions can approve pull requests.",
+			},
+			"include_actions_permissions": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to look up the GitHub Actions permissions of the repository, which costs one or two extra requests.",
+			},
+			"actions_permissions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The GitHub Actions permissions of the repository, when include_actions_permissions is set.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether GitHub Actions is enabled on the repository.",
+						},
+						"allowed_actions": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The policy that controls the actions that are allowed to run, one of 'all', 'local_only' or 'selected'.",
+						},
+						"sha_pinning_required": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether actions and reusable workflows must be pinned to a full commit SHA.",
+						},
+						"allowed_actions_config": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The actions that are allowed to run, when allowed_actions is 'selected'.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"github_owned_allowed": {
+										Type:        schema.TypeBool,
+										Computed:    true,
+										Description: "Whether GitHub-owned actions are allowed.",
+									},
+									"verified_allowed": {
+										Type:        schema.TypeBool,
+										Computed:    true,
+										Description: "Whether GitHub Marketplace actions by verified creators are allowed.",
+									},
+									"patterns_allowed": {
+										Type:        schema.TypeList,
+										Computed:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "The patterns of the actions that are allowed.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -414,6 +531,11 @@ func dataSourceGithubRepositoryRead(ctx context.Context, d *schema.ResourceData,
 	_ = d.Set("has_projects", repo.GetHasProjects())
 	_ = d.Set("delete_branch_on_merge", repo.GetDeleteBranchOnMerge())
 	_ = d.Set("allow_update_branch", repo.GetAllowUpdateBranch())
+	_ = d.Set("fork_count", repo.GetForksCount())
+	_ = d.Set("stargazer_count", repo.GetStargazersCount())
+	// The REST API reports stargazers as watchers_count, actual watchers are
+	// returned as subscribers_count.
+	_ = d.Set("watcher_count", repo.GetSubscribersCount())
 
 	if repo.GetHasPages() {
 		pages, _, err := client.Repositories.GetPagesInfo(ctx, owner, repoName)
@@ -459,14 +581,87 @@ func dataSourceGithubRepositoryRead(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
+	if err := d.Set("parent", flattenForkRepository(repo.Parent)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("source", flattenForkRepository(repo.Source)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	err = d.Set("topics", flattenStringList(repo.Topics))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if d.Get("include_workflow_permissions").(bool) {
+		permissions, _, err := client.Repositories.GetDefaultWorkflowPermissions(ctx, owner, repoName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		_ = d.Set("default_workflow_permissions", permissions.GetDefaultWorkflowPermissions())
+		_ = d.Set("can_approve_pull_request_reviews", permissions.GetCanApprovePullRequestReviews())
+	}
+
+	if d.Get("include_actions_permissions").(bool) {
+		actionsPermissions, err := readRepositoryActionsPermissions(ctx, client, owner, repoName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("actions_permissions", actionsPermissions); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return nil
 }
 
+// readRepositoryActionsPermissions returns the flattened GitHub Actions
+// permissions of a repository, only looking up the allowed actions when the
+// repository is restricted to selected actions.
+func readRepositoryActionsPermissions(ctx context.Context, client *github.Client, owner, repoName string) ([]any, error) {
+	permissions, _, err := client.Repositories.GetActionsPermissions(ctx, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	allowedActionsConfig := make([]any, 0)
+	if permissions.GetEnabled() && permissions.GetAllowedActions() == "selected" {
+		allowed, _, err := client.Repositories.GetActionsAllowed(ctx, owner, repoName)
+		if err != nil {
+			return nil, err
+		}
+		allowedActionsConfig = append(allowedActionsConfig, map[string]any{
+			"github_owned_allowed": allowed.GetGithubOwnedAllowed(),
+			"verified_allowed":     allowed.GetVerifiedAllowed(),
+			"patterns_allowed":     allowed.PatternsAllowed,
+		})
+	}
+
+	return []any{
+		map[string]any{
+			"enabled":                permissions.GetEnabled(),
+			"allowed_actions":        permissions.GetAllowedActions(),
+			"sha_pinning_required":   permissions.GetSHAPinningRequired(),
+			"allowed_actions_config": allowedActionsConfig,
+		},
+	}, nil
+}
+
+// flattenForkRepository flattens the parent or source repository of a fork,
+// returning an empty list for repositories that are not forks.
+func flattenForkRepository(repo *github.Repository) []any {
+	if repo == nil {
+		return []any{}
+	}
+
+	return []any{
+		map[string]any{
+			"owner":      repo.GetOwner().GetLogin(),
+			"repository": repo.GetName(),
+		},
+	}
+}
+
 func splitRepoFullName(fullName string) (string, string, error) {
 	parts := strings.Split(fullName, "/")
 	if len(parts) != 2 {
@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"net/url"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -52,7 +53,7 @@ func dataSourceGithubRepositoryDeploymentBranchPoliciesRead(d *schema.ResourceDa
 	repoName := d.Get("repository").(string)
 	environmentName := d.Get("environment_name").(string)
 
-	policies, _, err := client.Repositories.ListDeploymentBranchPolicies(context.Background(), owner, repoName, environmentName)
+	policies, _, err := client.Repositories.ListDeploymentBranchPolicies(context.Background(), owner, repoName, url.PathEscape(environmentName))
 	if err != nil {
 		// TODO: Remove nolint once we can return an error
 		return nil //nolint:nilerr
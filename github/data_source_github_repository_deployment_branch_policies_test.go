@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestAccGithubRepositoryDeploymentBranchPolicies(t *testing.T) {
@@ -61,3 +62,26 @@ func TestAccGithubRepositoryDeploymentBranchPolicies(t *testing.T) {
 		})
 	})
 }
+
+func TestGithubRepositoryDeploymentBranchPoliciesEscapesEnvironmentName(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/environments/prod%2Feu/deployment-branch-policies",
+			ResponseBody: `{"total_count": 1, "branch_policies": [{"id": 1, "name": "main"}]}`,
+			StatusCode:   200,
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceGithubRepositoryDeploymentBranchPolicies().Schema, map[string]any{
+		"repository":       "test-repo",
+		"environment_name": "prod/eu",
+	})
+
+	if err := dataSourceGithubRepositoryDeploymentBranchPoliciesRead(d, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := d.Get("deployment_branch_policies.#"); got != 1 {
+		t.Fatalf("expected 1 deployment branch policy for environment prod/eu, got %v", got)
+	}
+}
@@ -0,0 +1,319 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+
+	"github.com/google/go-github/v82/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubRepositoryEnvironment() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGithubRepositoryEnvironmentRead,
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the GitHub repository.",
+			},
+			"environment": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the environment.",
+			},
+			"sort_reviewers": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Sort the reviewer team and user IDs in ascending order instead of the order returned by GitHub.",
+			},
+			"include_deployment_count": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to look up the number of deployments targeting the environment, which costs an extra request.",
+			},
+			"deployment_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of deployments targeting the environment, when include_deployment_count is set.",
+			},
+			"can_admins_bypass": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether admins can bypass deployment protections.",
+			},
+			"admins_bypass_effective": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether admins can bypass deployment protections and the environment has protection rules to bypass.",
+			},
+			"prevent_self_review": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether users are prevented from approving workflow runs that they triggered.",
+			},
+			"wait_timer": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Amount of time to delay a job after the job is initially triggered.",
+			},
+			"reviewers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The environment reviewers configuration.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"teams": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "IDs of the teams who may review jobs that reference the environment.",
+						},
+						"users": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "IDs of the users who may review jobs that reference the environment.",
+						},
+					},
+				},
+			},
+			"protection_rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The protection rules of the environment, in the order they are applied by GitHub.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the protection rule.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the protection rule.",
+						},
+						"wait_timer": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The wait timer in minutes, for wait_timer rules.",
+						},
+						"prevent_self_review": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether self review is prevented, for required_reviewers rules.",
+						},
+					},
+				},
+			},
+			"deployment_branch_policy": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The deployment branch policy configuration.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"protected_branches": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether only branches with branch protection rules can deploy to this environment.",
+						},
+						"custom_branch_policies": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether only branches that match the specified name patterns can deploy to this environment.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGithubRepositoryEnvironmentRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repoName := d.Get("repository").(string)
+	envName := d.Get("environment").(string)
+	sortReviewers := d.Get("sort_reviewers").(bool)
+
+	cacheKey := owner + "/" + repoName + "/" + envName
+	cached, hasCached := meta.(*Owner).environmentETags.get(cacheKey)
+	// The ETag only applies to the environment itself, not to the other
+	// requests made below.
+	envCtx := ctx
+	if hasCached {
+		envCtx = context.WithValue(ctx, ctxEtag, cached.etag)
+	}
+
+	env, resp, err := client.Repositories.GetEnvironment(envCtx, owner, repoName, url.PathEscape(envName))
+	if err != nil {
+		var ghErr *github.ErrorResponse
+		if !hasCached || !errors.As(err, &ghErr) || ghErr.Response.StatusCode != http.StatusNotModified {
+			return environmentReadErrorDiagnostics(err, repoName, envName)
+		}
+		env = cached.environment
+	} else if etag := resp.Header.Get("ETag"); etag != "" {
+		meta.(*Owner).environmentETags.set(cacheKey, environmentETagEntry{etag: etag, environment: env})
+	}
+
+	id, err := buildID(repoName, escapeIDPart(envName))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	_ = d.Set("can_admins_bypass", env.GetCanAdminsBypass())
+	_ = d.Set("admins_bypass_effective", env.GetCanAdminsBypass() && len(env.ProtectionRules) > 0)
+	_ = d.Set("wait_timer", 0)
+	_ = d.Set("prevent_self_review", false)
+	_ = d.Set("reviewers", []any{})
+
+	protectionRules := make([]any, 0, len(env.ProtectionRules))
+	for _, pr := range env.ProtectionRules {
+		protectionRules = append(protectionRules, map[string]any{
+			"id":                  pr.GetID(),
+			"type":                pr.GetType(),
+			"wait_timer":          pr.GetWaitTimer(),
+			"prevent_self_review": pr.GetPreventSelfReview(),
+		})
+
+		switch pr.GetType() {
+		case "wait_timer":
+			_ = d.Set("wait_timer", pr.GetWaitTimer())
+
+		case "required_reviewers":
+			teams, users := flattenEnvironmentReviewerIDs(pr.Reviewers, sortReviewers)
+			if err := d.Set("reviewers", []any{
+				map[string]any{
+					"teams": teams,
+					"users": users,
+				},
+			}); err != nil {
+				return diag.FromErr(err)
+			}
+			_ = d.Set("prevent_self_review", pr.GetPreventSelfReview())
+		}
+	}
+
+	if err := d.Set("protection_rules", protectionRules); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if env.DeploymentBranchPolicy != nil {
+		if err := d.Set("deployment_branch_policy", []any{
+			map[string]any{
+				"protected_branches":     env.DeploymentBranchPolicy.GetProtectedBranches(),
+				"custom_branch_policies": env.DeploymentBranchPolicy.GetCustomBranchPolicies(),
+			},
+		}); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		_ = d.Set("deployment_branch_policy", []any{})
+	}
+
+	deploymentCount := 0
+	if d.Get("include_deployment_count").(bool) {
+		deploymentCount, err = countEnvironmentDeployments(ctx, client, owner, repoName, envName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	_ = d.Set("deployment_count", deploymentCount)
+
+	return nil
+}
+
+// countEnvironmentDeployments returns the number of deployments targeting an
+// environment. Listing a single deployment per page makes the number of the
+// last page equal to the number of deployments.
+func countEnvironmentDeployments(ctx context.Context, client *github.Client, owner, repoName, envName string) (int, error) {
+	deployments, resp, err := client.Repositories.ListDeployments(ctx, owner, repoName, &github.DeploymentsListOptions{
+		Environment: envName,
+		ListOptions: github.ListOptions{PerPage: 1},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.LastPage > 0 {
+		return resp.LastPage, nil
+	}
+	return len(deployments), nil
+}
+
+// flattenEnvironmentReviewerIDs splits the reviewers of a required_reviewers
+// protection rule into team and user IDs, optionally sorted ascending.
+// Reviewers whose team or user was deleted come back without an ID, or with a
+// null reviewer, and are skipped so that they do not show up as drift.
+func flattenEnvironmentReviewerIDs(reviewers []*github.RequiredReviewer, sorted bool) ([]int64, []int64) {
+	teams := make([]int64, 0)
+	users := make([]int64, 0)
+
+	for _, r := range reviewers {
+		switch reviewer := r.Reviewer.(type) {
+		case *github.Team:
+			if id := reviewer.GetID(); id != 0 {
+				teams = append(teams, id)
+			}
+		case *github.User:
+			if id := reviewer.GetID(); id != 0 {
+				users = append(users, id)
+			}
+		}
+	}
+
+	if sorted {
+		slices.Sort(teams)
+		slices.Sort(users)
+	}
+
+	return teams, users
+}
+
+// environmentETagCache remembers the last environment returned for each
+// repository environment together with its ETag, so that repeated data source
+// reads can be served from a conditional request.
+type environmentETagCache struct {
+	mu      sync.Mutex
+	entries map[string]environmentETagEntry
+}
+
+type environmentETagEntry struct {
+	etag        string
+	environment *github.Environment
+}
+
+func (c *environmentETagCache) get(key string) (environmentETagEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+func (c *environmentETagCache) set(key string, entry environmentETagEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]environmentETagEntry)
+	}
+	c.entries[key] = entry
+}
+
+func (c *environmentETagCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = nil
+}
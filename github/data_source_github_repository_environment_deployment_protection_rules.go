@@ -0,0 +1,139 @@
+package github
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubRepositoryEnvironmentDeploymentProtectionRules() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGithubRepositoryEnvironmentDeploymentProtectionRulesRead,
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the GitHub repository.",
+			},
+			"environment": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the environment.",
+			},
+			"rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The custom deployment protection rules enabled for the environment.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the deployment protection rule.",
+						},
+						"integration_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the GitHub App providing the rule.",
+						},
+						"slug": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The slug of the GitHub App providing the rule.",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the deployment protection rule is enabled.",
+						},
+					},
+				},
+			},
+			"available_integrations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The GitHub Apps that can provide a custom deployment protection rule but are not enabled for the environment.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the GitHub App.",
+						},
+						"slug": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The slug of the GitHub App.",
+						},
+						"integration_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The API URL of the GitHub App.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGithubRepositoryEnvironmentDeploymentProtectionRulesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+
+	repoName := d.Get("repository").(string)
+	envName := d.Get("environment").(string)
+	escapedEnvName := url.PathEscape(envName)
+
+	enabled, _, err := client.Repositories.GetAllDeploymentProtectionRules(ctx, owner, repoName, escapedEnvName)
+	if err != nil {
+		return environmentReadErrorDiagnostics(err, repoName, envName)
+	}
+
+	available, _, err := client.Repositories.ListCustomDeploymentRuleIntegrations(ctx, owner, repoName, escapedEnvName)
+	if err != nil {
+		return environmentReadErrorDiagnostics(err, repoName, envName)
+	}
+
+	enabledApps := make(map[int64]bool)
+	rules := make([]any, 0, len(enabled.ProtectionRules))
+	for _, rule := range enabled.ProtectionRules {
+		enabledApps[rule.GetApp().GetID()] = true
+		rules = append(rules, map[string]any{
+			"id":             rule.GetID(),
+			"integration_id": rule.GetApp().GetID(),
+			"slug":           rule.GetApp().GetSlug(),
+			"enabled":        rule.GetEnabled(),
+		})
+	}
+
+	integrations := make([]any, 0, len(available.AvailableIntegrations))
+	for _, app := range available.AvailableIntegrations {
+		if enabledApps[app.GetID()] {
+			continue
+		}
+		integrations = append(integrations, map[string]any{
+			"id":              app.GetID(),
+			"slug":            app.GetSlug(),
+			"integration_url": app.GetIntegrationURL(),
+		})
+	}
+
+	id, err := buildID(repoName, escapeIDPart(envName))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	if err := d.Set("rules", rules); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("available_integrations", integrations); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
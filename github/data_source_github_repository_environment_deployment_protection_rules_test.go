@@ -0,0 +1,54 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceGithubRepositoryEnvironmentDeploymentProtectionRulesRead(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri: "/repos/test-owner/test-repo/environments/production/deployment_protection_rules",
+			ResponseBody: `{"total_count": 1, "custom_deployment_protection_rules": [
+				{"id": 3, "enabled": true, "app": {"id": 10, "slug": "gatekeeper"}}
+			]}`,
+			StatusCode: 200,
+		},
+		{
+			ExpectedUri: "/repos/test-owner/test-repo/environments/production/deployment_protection_rules/apps",
+			ResponseBody: `{"total_count": 2, "available_custom_deployment_protection_rule_integrations": [
+				{"id": 10, "slug": "gatekeeper", "integration_url": "https://api.github.com/apps/gatekeeper"},
+				{"id": 11, "slug": "change-freeze", "integration_url": "https://api.github.com/apps/change-freeze"}
+			]}`,
+			StatusCode: 200,
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceGithubRepositoryEnvironmentDeploymentProtectionRules().Schema, map[string]any{
+		"repository":  "test-repo",
+		"environment": "production",
+	})
+	if diags := dataSourceGithubRepositoryEnvironmentDeploymentProtectionRulesRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	rules := d.Get("rules").([]any)
+	if len(rules) != 1 {
+		t.Fatalf("expected 1 enabled rule, got %d", len(rules))
+	}
+	if rule := rules[0].(map[string]any); rule["id"] != 3 || rule["integration_id"] != 10 || rule["slug"] != "gatekeeper" || rule["enabled"] != true {
+		t.Errorf("unexpected rule: %v", rule)
+	}
+
+	// The enabled app is also listed as available by GitHub, but must only be
+	// reported once.
+	integrations := d.Get("available_integrations").([]any)
+	if len(integrations) != 1 {
+		t.Fatalf("expected 1 available integration, got %d", len(integrations))
+	}
+	if app := integrations[0].(map[string]any); app["id"] != 11 || app["slug"] != "change-freeze" {
+		t.Errorf("unexpected available integration: %v", app)
+	}
+}
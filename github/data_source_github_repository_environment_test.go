@@ -0,0 +1,337 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v82/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccGithubRepositoryEnvironmentDataSource(t *testing.T) {
+	t.Run("queries an environment", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		repoName := fmt.Sprintf("%srepo-env-%s", testResourcePrefix, randomID)
+		config := fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name       = "%s"
+				visibility = "public"
+			}
+
+			resource "github_repository_environment" "test" {
+				repository  = github_repository.test.name
+				environment = "env_x"
+				wait_timer  = 10
+			}
+
+			data "github_repository_environment" "test" {
+				repository     = github_repository.test.name
+				environment    = github_repository_environment.test.environment
+				sort_reviewers = true
+			}
+		`, repoName)
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnauthenticated(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("data.github_repository_environment.test", "environment", "env_x"),
+						resource.TestCheckResourceAttr("data.github_repository_environment.test", "wait_timer", "10"),
+						resource.TestCheckResourceAttr("data.github_repository_environment.test", "reviewers.#", "0"),
+					),
+				},
+			},
+		})
+	})
+}
+
+func TestGithubRepositoryEnvironmentDataSourceSortReviewers(t *testing.T) {
+	environmentResponse := func(reviewers string) string {
+		return fmt.Sprintf(`{
+			"name": "production",
+			"can_admins_bypass": true,
+			"protection_rules": [
+				{
+					"type": "required_reviewers",
+					"prevent_self_review": false,
+					"reviewers": [%s]
+				}
+			]
+		}`, reviewers)
+	}
+
+	team := func(id int) string { return fmt.Sprintf(`{"type": "Team", "reviewer": {"id": %d}}`, id) }
+	user := func(id int) string { return fmt.Sprintf(`{"type": "User", "reviewer": {"id": %d}}`, id) }
+
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/environments/production",
+			ResponseBody: environmentResponse(fmt.Sprintf("%s,%s,%s,%s", team(3), user(20), team(1), user(10))),
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/environments/production",
+			ResponseBody: environmentResponse(fmt.Sprintf("%s,%s,%s,%s", user(10), team(1), user(20), team(3))),
+			StatusCode:   200,
+		},
+	})
+
+	read := func() map[string]any {
+		d := schema.TestResourceDataRaw(t, dataSourceGithubRepositoryEnvironment().Schema, map[string]any{
+			"repository":     "test-repo",
+			"environment":    "production",
+			"sort_reviewers": true,
+		})
+
+		if diags := dataSourceGithubRepositoryEnvironmentRead(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+
+		return d.Get("reviewers").([]any)[0].(map[string]any)
+	}
+
+	first := read()
+	second := read()
+
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("expected identical reviewers across reads, got %v and %v", first, second)
+	}
+
+	expectedTeams := []any{1, 3}
+	if !reflect.DeepEqual(first["teams"], expectedTeams) {
+		t.Errorf("expected teams %v, got %v", expectedTeams, first["teams"])
+	}
+
+	expectedUsers := []any{10, 20}
+	if !reflect.DeepEqual(first["users"], expectedUsers) {
+		t.Errorf("expected users %v, got %v", expectedUsers, first["users"])
+	}
+}
+
+func TestGithubRepositoryEnvironmentDataSourceETag(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:     "/repos/test-owner/test-repo/environments/production",
+			ResponseBody:    `{"name": "production", "can_admins_bypass": false, "protection_rules": [{"type": "wait_timer", "wait_timer": 30}]}`,
+			ResponseHeaders: map[string]string{"ETag": `"abc123"`},
+			StatusCode:      200,
+		},
+		{
+			ExpectedUri:     "/repos/test-owner/test-repo/environments/production",
+			ExpectedHeaders: map[string]string{"If-None-Match": `"abc123"`},
+			// A not modified response must not be parsed, so any body is ignored.
+			ResponseBody: `not json`,
+			StatusCode:   304,
+		},
+	})
+	defer ts.Close()
+
+	client := github.NewClient(&http.Client{Transport: NewEtagTransport(http.DefaultTransport)})
+	u, _ := url.Parse(ts.URL + "/")
+	client.BaseURL = u
+
+	meta := &Owner{
+		name:     "test-owner",
+		v3client: client,
+	}
+
+	for i := range 2 {
+		d := schema.TestResourceDataRaw(t, dataSourceGithubRepositoryEnvironment().Schema, map[string]any{
+			"repository":  "test-repo",
+			"environment": "production",
+		})
+
+		if diags := dataSourceGithubRepositoryEnvironmentRead(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("read %d: unexpected error: %v", i, diags)
+		}
+
+		if got := d.Get("wait_timer"); got != 30 {
+			t.Errorf("read %d: expected wait_timer 30, got %v", i, got)
+		}
+		if got := d.Get("can_admins_bypass"); got != false {
+			t.Errorf("read %d: expected can_admins_bypass false, got %v", i, got)
+		}
+	}
+}
+
+func TestGithubRepositoryEnvironmentDataSourceETagOnlyForEnvironment(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		switch r.URL.Path {
+		case "/repos/test-owner/test-repo/environments/production":
+			if r.Header.Get("If-None-Match") != `"abc123"` {
+				t.Errorf("expected the environment request to send the cached ETag, got %q", r.Header.Get("If-None-Match"))
+			}
+			w.WriteHeader(http.StatusNotModified)
+		case "/repos/test-owner/test-repo/deployments":
+			if etag := r.Header.Get("If-None-Match"); etag != "" {
+				t.Errorf("expected the deployments request not to send the environment's ETag, got %q", etag)
+			}
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer ts.Close()
+
+	client := github.NewClient(&http.Client{Transport: NewEtagTransport(http.DefaultTransport)})
+	u, _ := url.Parse(ts.URL + "/")
+	client.BaseURL = u
+
+	meta := &Owner{
+		name:     "test-owner",
+		v3client: client,
+	}
+	meta.environmentETags.set("test-owner/test-repo/production", environmentETagEntry{
+		etag:        `"abc123"`,
+		environment: &github.Environment{Name: github.Ptr("production")},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceGithubRepositoryEnvironment().Schema, map[string]any{
+		"repository":               "test-repo",
+		"environment":              "production",
+		"include_deployment_count": true,
+	})
+
+	if diags := dataSourceGithubRepositoryEnvironmentRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+}
+
+func TestGithubRepositoryEnvironmentDataSourceProtectionRulesOrder(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri: "/repos/test-owner/test-repo/environments/production",
+			ResponseBody: `{
+				"name": "production",
+				"protection_rules": [
+					{"id": 1, "type": "wait_timer", "wait_timer": 15},
+					{"id": 2, "type": "required_reviewers", "prevent_self_review": true, "reviewers": [{"type": "User", "reviewer": {"id": 10}}]},
+					{"id": 3, "type": "branch_policy"}
+				]
+			}`,
+			StatusCode: 200,
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceGithubRepositoryEnvironment().Schema, map[string]any{
+		"repository":  "test-repo",
+		"environment": "production",
+	})
+
+	if diags := dataSourceGithubRepositoryEnvironmentRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	rules := d.Get("protection_rules").([]any)
+	expectedTypes := []string{"wait_timer", "required_reviewers", "branch_policy"}
+	if len(rules) != len(expectedTypes) {
+		t.Fatalf("expected %d protection rules, got %d", len(expectedTypes), len(rules))
+	}
+
+	for i, expected := range expectedTypes {
+		rule := rules[i].(map[string]any)
+		if rule["type"] != expected {
+			t.Errorf("expected protection rule %d to be %s, got %v", i, expected, rule["type"])
+		}
+		if rule["id"] != i+1 {
+			t.Errorf("expected protection rule %d to have id %d, got %v", i, i+1, rule["id"])
+		}
+	}
+
+	if got := rules[0].(map[string]any)["wait_timer"]; got != 15 {
+		t.Errorf("expected wait_timer 15, got %v", got)
+	}
+	if got := rules[1].(map[string]any)["prevent_self_review"]; got != true {
+		t.Errorf("expected prevent_self_review true, got %v", got)
+	}
+}
+
+func TestGithubRepositoryEnvironmentDataSourceDeploymentCount(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/environments/production",
+			ResponseBody: `{"name": "production"}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/deployments?environment=production&per_page=1",
+			ResponseBody: `[{"id": 100, "environment": "production"}]`,
+			ResponseHeaders: map[string]string{
+				"Link": `<https://api.github.com/repositories/1/deployments?environment=production&per_page=1&page=2>; rel="next", ` +
+					`<https://api.github.com/repositories/1/deployments?environment=production&per_page=1&page=7>; rel="last"`,
+			},
+			StatusCode: 200,
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceGithubRepositoryEnvironment().Schema, map[string]any{
+		"repository":               "test-repo",
+		"environment":              "production",
+		"include_deployment_count": true,
+	})
+
+	if diags := dataSourceGithubRepositoryEnvironmentRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("deployment_count"); got != 7 {
+		t.Errorf("expected deployment_count 7, got %v", got)
+	}
+}
+
+func TestGithubRepositoryEnvironmentDataSourceAdminsBypassEffective(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		response string
+		expected bool
+	}{
+		{
+			name:     "with protection rules",
+			response: `{"name": "production", "can_admins_bypass": true, "protection_rules": [{"type": "wait_timer", "wait_timer": 5}]}`,
+			expected: true,
+		},
+		{
+			name:     "without protection rules",
+			response: `{"name": "production", "can_admins_bypass": true, "protection_rules": []}`,
+			expected: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			meta := newMockOwner(t, []*mockResponse{
+				{
+					ExpectedUri:  "/repos/test-owner/test-repo/environments/production",
+					ResponseBody: tc.response,
+					StatusCode:   200,
+				},
+			})
+
+			d := schema.TestResourceDataRaw(t, dataSourceGithubRepositoryEnvironment().Schema, map[string]any{
+				"repository":  "test-repo",
+				"environment": "production",
+			})
+
+			if diags := dataSourceGithubRepositoryEnvironmentRead(context.Background(), d, meta); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if got := d.Get("can_admins_bypass"); got != true {
+				t.Errorf("expected can_admins_bypass true, got %v", got)
+			}
+			if got := d.Get("admins_bypass_effective"); got != tc.expected {
+				t.Errorf("expected admins_bypass_effective %t, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"slices"
 
 	"github.com/google/go-github/v82/github"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -17,6 +18,12 @@ func dataSourceGithubRepositoryEnvironments() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"unprotected_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only return environments without any protection rules or deployment branch policy.",
+			},
 			"environments": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -42,15 +49,23 @@ func dataSourceGithubRepositoryEnvironmentsRead(ctx context.Context, d *schema.R
 	orgName := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
 
+	unprotectedOnly := d.Get("unprotected_only").(bool)
+
 	results := make([]map[string]any, 0)
 
+	listOptions := &github.EnvironmentListOptions{}
 	for {
-		listOptions := &github.EnvironmentListOptions{}
 		environments, resp, err := client.Repositories.ListEnvironments(ctx, orgName, repoName, listOptions)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 
+		if unprotectedOnly && environments != nil {
+			environments.Environments = slices.DeleteFunc(environments.Environments, func(env *github.Environment) bool {
+				return len(env.ProtectionRules) > 0 || env.DeploymentBranchPolicy != nil
+			})
+		}
+
 		results = append(results, flattenEnvironments(environments)...)
 
 		if resp.NextPage == 0 {
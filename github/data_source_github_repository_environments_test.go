@@ -1,11 +1,13 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestAccGithubRepositoryEnvironmentsDataSource(t *testing.T) {
@@ -50,3 +52,44 @@ func TestAccGithubRepositoryEnvironmentsDataSource(t *testing.T) {
 		})
 	})
 }
+
+func TestGithubRepositoryEnvironmentsDataSourceUnprotectedOnly(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri: "/repos/test-owner/test-repo/environments",
+			ResponseBody: `{"total_count": 4, "environments": [
+				{"name": "production", "protection_rules": [{"type": "required_reviewers", "reviewers": [{"type": "User", "reviewer": {"id": 1}}]}]},
+				{"name": "staging", "protection_rules": [{"type": "wait_timer", "wait_timer": 5}]},
+				{"name": "release", "deployment_branch_policy": {"protected_branches": true, "custom_branch_policies": false}},
+				{"name": "preview", "protection_rules": []}
+			]}`,
+			ResponseHeaders: map[string]string{
+				"Link": `<https://api.github.com/repositories/1/environments?page=2>; rel="next"`,
+			},
+			StatusCode: 200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/environments?page=2",
+			ResponseBody: `{"total_count": 5, "environments": [{"name": "sandbox"}]}`,
+			StatusCode:   200,
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceGithubRepositoryEnvironments().Schema, map[string]any{
+		"repository":       "test-repo",
+		"unprotected_only": true,
+	})
+	if diags := dataSourceGithubRepositoryEnvironmentsRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	environments := d.Get("environments").([]any)
+	if len(environments) != 2 {
+		t.Fatalf("expected 2 unprotected environments, got %v", environments)
+	}
+	for i, expected := range []string{"preview", "sandbox"} {
+		if got := environments[i].(map[string]any)["name"]; got != expected {
+			t.Errorf("expected environment %d to be %s, got %v", i, expected, got)
+		}
+	}
+}
@@ -1,11 +1,14 @@
 package github
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestAccDataSourceGithubRepository(t *testing.T) {
@@ -380,3 +383,181 @@ EOT
 		})
 	})
 }
+
+func TestDataSourceGithubRepositoryForkNetwork(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri: "/repos/test-owner/fork-of-fork",
+			ResponseBody: `{
+				"name": "fork-of-fork",
+				"full_name": "test-owner/fork-of-fork",
+				"fork": true,
+				"parent": {"name": "fork", "owner": {"login": "middle-owner"}},
+				"source": {"name": "upstream", "owner": {"login": "root-owner"}}
+			}`,
+			StatusCode: 200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/not-a-fork",
+			ResponseBody: `{"name": "not-a-fork", "full_name": "test-owner/not-a-fork"}`,
+			StatusCode:   200,
+		},
+	})
+
+	read := func(name string) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, dataSourceGithubRepository().Schema, map[string]any{
+			"name": name,
+		})
+		if diags := dataSourceGithubRepositoryRead(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		return d
+	}
+
+	fork := read("fork-of-fork")
+
+	expectedParent := []any{map[string]any{"owner": "middle-owner", "repository": "fork"}}
+	if got := fork.Get("parent"); !reflect.DeepEqual(got, expectedParent) {
+		t.Errorf("expected parent %v, got %v", expectedParent, got)
+	}
+
+	expectedSource := []any{map[string]any{"owner": "root-owner", "repository": "upstream"}}
+	if got := fork.Get("source"); !reflect.DeepEqual(got, expectedSource) {
+		t.Errorf("expected source %v, got %v", expectedSource, got)
+	}
+
+	notFork := read("not-a-fork")
+
+	if got := notFork.Get("parent").([]any); len(got) != 0 {
+		t.Errorf("expected no parent for a non-fork, got %v", got)
+	}
+	if got := notFork.Get("source").([]any); len(got) != 0 {
+		t.Errorf("expected no source for a non-fork, got %v", got)
+	}
+}
+
+func TestDataSourceGithubRepositoryArchived(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:  "/repos/test-owner/archived-repo",
+			ResponseBody: `{"name": "archived-repo", "full_name": "test-owner/archived-repo", "description": "old", "archived": true}`,
+			StatusCode:   200,
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceGithubRepository().Schema, map[string]any{
+		"name": "archived-repo",
+	})
+	if diags := dataSourceGithubRepositoryRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// Unlike the resources, the data source keeps archived repositories.
+	if d.Id() != "archived-repo" {
+		t.Fatalf("expected the archived repository to be read, got id %q", d.Id())
+	}
+	if got := d.Get("archived"); got != true {
+		t.Errorf("expected archived to be true, got %v", got)
+	}
+	if got := d.Get("description"); got != "old" {
+		t.Errorf("expected description to be read, got %v", got)
+	}
+}
+
+func TestDataSourceGithubRepositoryCounts(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:  "/repos/test-owner/popular-repo",
+			ResponseBody: `{"name": "popular-repo", "forks_count": 12, "stargazers_count": 340, "watchers_count": 340, "subscribers_count": 25}`,
+			StatusCode:   200,
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceGithubRepository().Schema, map[string]any{
+		"name": "popular-repo",
+	})
+	if diags := dataSourceGithubRepositoryRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	for key, expected := range map[string]int{
+		"fork_count":      12,
+		"stargazer_count": 340,
+		"watcher_count":   25,
+	} {
+		if got := d.Get(key); got != expected {
+			t.Errorf("expected %s to be %d, got %v", key, expected, got)
+		}
+	}
+}
+
+func TestDataSourceGithubRepositoryWorkflowPermissions(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo",
+			ResponseBody: `{"name": "test-repo"}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/actions/permissions/workflow",
+			ResponseBody: `{"default_workflow_permissions": "write", "can_approve_pull_request_reviews": true}`,
+			StatusCode:   200,
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceGithubRepository().Schema, map[string]any{
+		"name":                         "test-repo",
+		"include_workflow_permissions": true,
+	})
+	if diags := dataSourceGithubRepositoryRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("default_workflow_permissions"); got != "write" {
+		t.Errorf("expected default_workflow_permissions to be write, got %v", got)
+	}
+	if got := d.Get("can_approve_pull_request_reviews"); got != true {
+		t.Errorf("expected can_approve_pull_request_reviews to be true, got %v", got)
+	}
+}
+
+func TestDataSourceGithubRepositoryActionsPermissions(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo",
+			ResponseBody: `{"name": "test-repo"}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/actions/permissions",
+			ResponseBody: `{"enabled": true, "allowed_actions": "selected", "sha_pinning_required": true}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/actions/permissions/selected-actions",
+			ResponseBody: `{"github_owned_allowed": true, "verified_allowed": false, "patterns_allowed": ["monalisa/octocat@*"]}`,
+			StatusCode:   200,
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceGithubRepository().Schema, map[string]any{
+		"name":                        "test-repo",
+		"include_actions_permissions": true,
+	})
+	if diags := dataSourceGithubRepositoryRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	for key, expected := range map[string]any{
+		"actions_permissions.0.enabled":                                       true,
+		"actions_permissions.0.allowed_actions":                               "selected",
+		"actions_permissions.0.sha_pinning_required":                          true,
+		"actions_permissions.0.allowed_actions_config.0.github_owned_allowed": true,
+		"actions_permissions.0.allowed_actions_config.0.verified_allowed":     false,
+		"actions_permissions.0.allowed_actions_config.0.patterns_allowed.0":   "monalisa/octocat@*",
+	} {
+		if got := d.Get(key); got != expected {
+			t.Errorf("expected %s to be %v, got %v", key, expected, got)
+		}
+	}
+}
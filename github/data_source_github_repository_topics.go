@@ -0,0 +1,46 @@
+package github
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubRepositoryTopics() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGithubRepositoryTopicsRead,
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the GitHub repository.",
+			},
+			"topics": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The topics of the repository.",
+			},
+		},
+	}
+}
+
+func dataSourceGithubRepositoryTopicsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repoName := d.Get("repository").(string)
+
+	topics, _, err := client.Repositories.ListAllTopics(ctx, owner, repoName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(repoName)
+	if err := d.Set("topics", flattenStringList(topics)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package github
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceGithubRepositoryTopicsRead(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		response string
+		expected []string
+	}{
+		{
+			name:     "with several topics",
+			response: `{"names": ["terraform", "github", "infrastructure-as-code"]}`,
+			expected: []string{"terraform", "github", "infrastructure-as-code"},
+		},
+		{
+			name:     "without topics",
+			response: `{"names": []}`,
+			expected: []string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			meta := newMockOwner(t, []*mockResponse{
+				{
+					ExpectedUri:  "/repos/test-owner/test-repo/topics",
+					ResponseBody: tc.response,
+					StatusCode:   200,
+				},
+			})
+
+			d := schema.TestResourceDataRaw(t, dataSourceGithubRepositoryTopics().Schema, map[string]any{
+				"repository": "test-repo",
+			})
+			if diags := dataSourceGithubRepositoryTopicsRead(context.Background(), d, meta); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if got := d.Id(); got != "test-repo" {
+				t.Errorf("expected id test-repo, got %s", got)
+			}
+			if got := expandStringList(d.Get("topics").([]any)); !slices.Equal(got, tc.expected) {
+				t.Errorf("expected topics %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package github
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubTokenScopes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGithubTokenScopesRead,
+
+		Schema: map[string]*schema.Schema{
+			"required_scopes": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The OAuth scopes the token is expected to have. Defaults to 'repo', plus 'read:org' when the provider is configured for an organization.",
+			},
+			"scopes_reported": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether GitHub reported the scopes of the token. Only classic personal access tokens and OAuth tokens have scopes.",
+			},
+			"scopes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The OAuth scopes granted to the token.",
+			},
+			"missing_scopes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The required scopes that are neither granted to the token nor implied by a granted scope.",
+			},
+		},
+	}
+}
+
+func dataSourceGithubTokenScopesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+
+	// The rate limit endpoint is available to every kind of token and does not
+	// count against the rate limit itself.
+	_, resp, err := client.RateLimit.Get(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	header, reported := resp.Header["X-Oauth-Scopes"]
+	scopes := make([]string, 0)
+	if reported && len(header) > 0 {
+		for _, scope := range strings.Split(header[0], ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+
+	required := expandStringList(d.Get("required_scopes").([]any))
+	if len(required) == 0 {
+		required = []string{"repo"}
+		if meta.(*Owner).IsOrganization {
+			required = append(required, "read:org")
+		}
+	}
+
+	// Tokens without scopes, such as fine-grained tokens and GitHub App
+	// tokens, use permissions instead, which cannot be checked here.
+	missing := make([]string, 0)
+	if reported {
+		for _, scope := range required {
+			if !hasTokenScope(scopes, scope) {
+				missing = append(missing, scope)
+			}
+		}
+	}
+
+	d.SetId(meta.(*Owner).name)
+	_ = d.Set("scopes_reported", reported)
+	if err := d.Set("scopes", scopes); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("missing_scopes", missing); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// impliedTokenScopes lists the scopes that are granted implicitly, directly or
+// transitively, by a parent scope, see https://docs.github.com/en/apps/oauth-apps/building-oauth-apps/scopes-for-oauth-apps
+var impliedTokenScopes = map[string][]string{
+	"repo":             {"repo:status", "repo_deployment", "public_repo", "repo:invite", "security_events"},
+	"admin:org":        {"write:org", "read:org", "manage_runners:org"},
+	"write:org":        {"read:org"},
+	"admin:public_key": {"write:public_key", "read:public_key"},
+	"write:public_key": {"read:public_key"},
+	"admin:repo_hook":  {"write:repo_hook", "read:repo_hook"},
+	"write:repo_hook":  {"read:repo_hook"},
+	"admin:gpg_key":    {"write:gpg_key", "read:gpg_key"},
+	"write:gpg_key":    {"read:gpg_key"},
+	"user":             {"read:user", "user:email", "user:follow"},
+	"write:packages":   {"read:packages"},
+	"admin:enterprise": {"manage_runners:enterprise", "manage_billing:enterprise", "read:enterprise"},
+}
+
+func hasTokenScope(granted []string, scope string) bool {
+	for _, g := range granted {
+		if g == scope || slices.Contains(impliedTokenScopes[g], scope) {
+			return true
+		}
+	}
+	return false
+}
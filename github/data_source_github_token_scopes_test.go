@@ -0,0 +1,75 @@
+package github
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceGithubTokenScopesRead(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		headers         map[string]string
+		required        []any
+		isOrganization  bool
+		expectedScopes  []any
+		expectedMissing []any
+		reported        bool
+	}{
+		{
+			name:            "defaults for an organization",
+			headers:         map[string]string{"X-OAuth-Scopes": "repo, admin:org"},
+			isOrganization:  true,
+			expectedScopes:  []any{"repo", "admin:org"},
+			expectedMissing: []any{},
+			reported:        true,
+		},
+		{
+			name:            "missing required scopes",
+			headers:         map[string]string{"X-OAuth-Scopes": "public_repo, write:org"},
+			required:        []any{"repo", "read:org", "admin:repo_hook"},
+			expectedScopes:  []any{"public_repo", "write:org"},
+			expectedMissing: []any{"repo", "admin:repo_hook"},
+			reported:        true,
+		},
+		{
+			name:            "token without scopes",
+			required:        []any{"repo"},
+			expectedScopes:  []any{},
+			expectedMissing: []any{},
+			reported:        false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			meta := newMockOwner(t, []*mockResponse{
+				{
+					ExpectedUri:     "/rate_limit",
+					ResponseBody:    `{"resources": {"core": {"limit": 5000, "remaining": 4999}}}`,
+					ResponseHeaders: tc.headers,
+					StatusCode:      200,
+				},
+			})
+			meta.IsOrganization = tc.isOrganization
+
+			d := schema.TestResourceDataRaw(t, dataSourceGithubTokenScopes().Schema, map[string]any{
+				"required_scopes": tc.required,
+			})
+
+			if diags := dataSourceGithubTokenScopesRead(context.Background(), d, meta); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if got := d.Get("scopes_reported"); got != tc.reported {
+				t.Errorf("expected scopes_reported %t, got %v", tc.reported, got)
+			}
+			if got := d.Get("scopes"); !reflect.DeepEqual(got, tc.expectedScopes) {
+				t.Errorf("expected scopes %v, got %v", tc.expectedScopes, got)
+			}
+			if got := d.Get("missing_scopes"); !reflect.DeepEqual(got, tc.expectedMissing) {
+				t.Errorf("expected missing scopes %v, got %v", tc.expectedMissing, got)
+			}
+		})
+	}
+}
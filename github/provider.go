@@ -10,8 +10,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/go-github/v82/github"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
@@ -130,6 +133,74 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("GITHUB_MAX_PER_PAGE", "100"),
 				Description: descriptions["max_per_page"],
 			},
+			"validate_environment_reviewers": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["validate_environment_reviewers"],
+			},
+			"warn_on_disabled_actions": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["warn_on_disabled_actions"],
+			},
+			"max_wait_timer": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: descriptions["max_wait_timer"],
+			},
+			"environment_defaults": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["environment_defaults"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"wait_timer": {
+							Type:             schema.TypeInt,
+							Optional:         true,
+							ValidateDiagFunc: toDiagFunc(validation.IntBetween(0, 43200), "wait_timer"),
+							Description:      "Default amount of time to delay a job after the job is initially triggered.",
+						},
+						"can_admins_bypass": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Default for whether admins can bypass deployment protections.",
+						},
+						"prevent_self_review": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Default for whether users are prevented from approving workflow runs that they triggered.",
+						},
+					},
+				},
+			},
+			"user_agent_suffix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: descriptions["user_agent_suffix"],
+			},
+			"requests_per_hour": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: descriptions["requests_per_hour"],
+			},
+			"api_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: descriptions["api_version"],
+			},
+			"dry_run": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["dry_run"],
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -195,7 +266,9 @@ func Provider() *schema.Provider {
 			"github_repository_deploy_key":                                          resourceGithubRepositoryDeployKey(),
 			"github_repository_deployment_branch_policy":                            resourceGithubRepositoryDeploymentBranchPolicy(),
 			"github_repository_environment":                                         resourceGithubRepositoryEnvironment(),
+			"github_repository_environment_deployment_policies":                     resourceGithubRepositoryEnvironmentDeploymentPolicies(),
 			"github_repository_environment_deployment_policy":                       resourceGithubRepositoryEnvironmentDeploymentPolicy(),
+			"github_repository_environment_reviewers":                               resourceGithubRepositoryEnvironmentReviewers(),
 			"github_repository_file":                                                resourceGithubRepositoryFile(),
 			"github_repository_milestone":                                           resourceGithubRepositoryMilestone(),
 			"github_repository_project":                                             resourceGithubRepositoryProject(),
@@ -238,6 +311,7 @@ func Provider() *schema.Provider {
 			"github_app_token":                                                      dataSourceGithubAppToken(),
 			"github_branch":                                                         dataSourceGithubBranch(),
 			"github_branch_protection_rules":                                        dataSourceGithubBranchProtectionRules(),
+			"github_cache_flush":                                                    dataSourceGithubCacheFlush(),
 			"github_collaborators":                                                  dataSourceGithubCollaborators(),
 			"github_codespaces_organization_public_key":                             dataSourceGithubCodespacesOrganizationPublicKey(),
 			"github_codespaces_organization_secrets":                                dataSourceGithubCodespacesOrganizationSecrets(),
@@ -256,6 +330,7 @@ func Provider() *schema.Provider {
 			"github_organization":                                                   dataSourceGithubOrganization(),
 			"github_organization_custom_role":                                       dataSourceGithubOrganizationCustomRole(),
 			"github_organization_custom_properties":                                 dataSourceGithubOrganizationCustomProperties(),
+			"github_organization_environments":                                      dataSourceGithubOrganizationEnvironments(),
 			"github_organization_external_identities":                               dataSourceGithubOrganizationExternalIdentities(),
 			"github_organization_ip_allow_list":                                     dataSourceGithubOrganizationIpAllowList(),
 			"github_organization_repository_role":                                   dataSourceGithubOrganizationRepositoryRole(),
@@ -276,6 +351,8 @@ func Provider() *schema.Provider {
 			"github_repository_autolink_references":                                 dataSourceGithubRepositoryAutolinkReferences(),
 			"github_repository_branches":                                            dataSourceGithubRepositoryBranches(),
 			"github_repository_custom_properties":                                   dataSourceGithubRepositoryCustomProperties(),
+			"github_repository_environment":                                         dataSourceGithubRepositoryEnvironment(),
+			"github_repository_environment_deployment_protection_rules":             dataSourceGithubRepositoryEnvironmentDeploymentProtectionRules(),
 			"github_repository_environments":                                        dataSourceGithubRepositoryEnvironments(),
 			"github_repository_deploy_keys":                                         dataSourceGithubRepositoryDeployKeys(),
 			"github_repository_deployment_branch_policies":                          dataSourceGithubRepositoryDeploymentBranchPolicies(),
@@ -284,10 +361,12 @@ func Provider() *schema.Provider {
 			"github_repository_pull_request":                                        dataSourceGithubRepositoryPullRequest(),
 			"github_repository_pull_requests":                                       dataSourceGithubRepositoryPullRequests(),
 			"github_repository_teams":                                               dataSourceGithubRepositoryTeams(),
+			"github_repository_topics":                                              dataSourceGithubRepositoryTopics(),
 			"github_repository_webhooks":                                            dataSourceGithubRepositoryWebhooks(),
 			"github_rest_api":                                                       dataSourceGithubRestApi(),
 			"github_ssh_keys":                                                       dataSourceGithubSshKeys(),
 			"github_team":                                                           dataSourceGithubTeam(),
+			"github_token_scopes":                                                   dataSourceGithubTokenScopes(),
 			"github_tree":                                                           dataSourceGithubTree(),
 			"github_user":                                                           dataSourceGithubUser(),
 			"github_user_external_identity":                                         dataSourceGithubUserExternalIdentity(),
@@ -341,6 +420,23 @@ func init() {
 			"Defaults to 3",
 		"max_per_page": "Number of items per page for pagination" +
 			"Defaults to 100",
+		"validate_environment_reviewers": "Validate at plan time that the team reviewers of repository environments " +
+			"belong to the configured organization. This costs one API call per team reviewer. " +
+			"Defaults to false",
+		"warn_on_disabled_actions": "Warn when creating a repository environment on a repository with GitHub Actions " +
+			"disabled. This costs one extra API call per environment created.",
+		"max_wait_timer": "The highest wait_timer, in minutes, that github_repository_environment accepts at plan time, " +
+			"for GitHub plans with a lower limit than GitHub's own 43200 minutes. Defaults to 0, which means no extra limit.",
+		"environment_defaults": "Default settings applied to every github_repository_environment that omits them. " +
+			"Settings on the resource take precedence.",
+		"user_agent_suffix": "A string appended to the User-Agent header of every request made to GitHub, " +
+			"for example to identify the calling pipeline in audit logs.",
+		"requests_per_hour": "The maximum number of requests the provider sends to GitHub per hour, across all resources and " +
+			"data sources. Requests wait for the budget to refill instead of failing. Defaults to 0, which means no limit.",
+		"api_version": "The version of the REST API to request with the X-GitHub-Api-Version header, in YYYY-MM-DD format. " +
+			"Defaults to the version the provider was built and tested against.",
+		"dry_run": "Log the create, update and delete API calls of github_repository_environment and " +
+			"github_team_repository instead of making them, and store the planned values in state. Defaults to false.",
 	}
 }
 
@@ -473,6 +569,39 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 
 		log.Printf("[DEBUG] Setting parallel_requests to %t", parallelRequests)
 
+		validateEnvironmentReviewers := d.Get("validate_environment_reviewers").(bool)
+		log.Printf("[DEBUG] Setting validate_environment_reviewers to %t", validateEnvironmentReviewers)
+
+		warnOnDisabledActions := d.Get("warn_on_disabled_actions").(bool)
+		log.Printf("[DEBUG] Setting warn_on_disabled_actions to %t", warnOnDisabledActions)
+
+		maxWaitTimer := d.Get("max_wait_timer").(int)
+		if maxWaitTimer < 0 || maxWaitTimer > 43200 {
+			return nil, diag.FromErr(fmt.Errorf("max_wait_timer must be between 0 and 43200, got %d", maxWaitTimer))
+		}
+		log.Printf("[DEBUG] Setting max_wait_timer to %d", maxWaitTimer)
+
+		environmentDefaults := expandEnvironmentDefaults(d.GetRawConfig().GetAttr("environment_defaults"))
+		log.Printf("[DEBUG] Setting environment_defaults to %+v", environmentDefaults)
+
+		userAgentSuffix := d.Get("user_agent_suffix").(string)
+		log.Printf("[DEBUG] Setting user_agent_suffix to %q", userAgentSuffix)
+
+		requestsPerHour := d.Get("requests_per_hour").(int)
+		if requestsPerHour < 0 {
+			return nil, diag.FromErr(fmt.Errorf("requests_per_hour must be greater than or equal to 0"))
+		}
+		log.Printf("[DEBUG] Setting requests_per_hour to %d", requestsPerHour)
+
+		apiVersion := d.Get("api_version").(string)
+		if apiVersion != "" && !apiVersionRegexp.MatchString(apiVersion) {
+			return nil, diag.FromErr(fmt.Errorf("api_version must be a date in YYYY-MM-DD format, got %q", apiVersion))
+		}
+		log.Printf("[DEBUG] Setting api_version to %q", apiVersion)
+
+		dryRun := d.Get("dry_run").(bool)
+		log.Printf("[DEBUG] Setting dry_run to %t", dryRun)
+
 		config := Config{
 			Token:            token,
 			BaseURL:          baseURL,
@@ -484,7 +613,16 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			RetryableErrors:  retryableErrors,
 			MaxRetries:       maxRetries,
 			ParallelRequests: parallelRequests,
+			RequestsPerHour:  requestsPerHour,
 			IsGHES:           isGHES,
+
+			ValidateEnvironmentReviewers: validateEnvironmentReviewers,
+			WarnOnDisabledActions:        warnOnDisabledActions,
+			MaxWaitTimer:                 maxWaitTimer,
+			EnvironmentDefaults:          environmentDefaults,
+			UserAgentSuffix:              userAgentSuffix,
+			APIVersion:                   apiVersion,
+			DryRun:                       dryRun,
 		}
 
 		meta, err := config.Meta()
@@ -496,6 +634,31 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 	}
 }
 
+// expandEnvironmentDefaults reads the environment_defaults block from the raw
+// provider configuration, since only it tells a setting left out apart from
+// one explicitly set to its zero value.
+func expandEnvironmentDefaults(v cty.Value) *EnvironmentDefaults {
+	if v.IsNull() || !v.IsKnown() || v.LengthInt() == 0 {
+		return nil
+	}
+
+	block := v.Index(cty.NumberIntVal(0))
+	defaults := &EnvironmentDefaults{}
+
+	if attr := block.GetAttr("wait_timer"); attr.IsKnown() && !attr.IsNull() {
+		waitTimer, _ := attr.AsBigFloat().Int64()
+		defaults.WaitTimer = github.Ptr(int(waitTimer))
+	}
+	if attr := block.GetAttr("can_admins_bypass"); attr.IsKnown() && !attr.IsNull() {
+		defaults.CanAdminsBypass = github.Ptr(attr.True())
+	}
+	if attr := block.GetAttr("prevent_self_review"); attr.IsKnown() && !attr.IsNull() {
+		defaults.PreventSelfReview = github.Ptr(attr.True())
+	}
+
+	return defaults
+}
+
 // See https://github.com/integrations/terraform-provider-github/issues/1822
 func tokenFromGHCLI(u *url.URL) string {
 	ghCliPath := os.Getenv("GH_PATH")
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -259,3 +260,35 @@ data "github_ip_ranges" "test" {}
 		})
 	})
 }
+
+func TestExpandEnvironmentDefaults(t *testing.T) {
+	blockType := cty.Object(map[string]cty.Type{
+		"wait_timer":          cty.Number,
+		"can_admins_bypass":   cty.Bool,
+		"prevent_self_review": cty.Bool,
+	})
+
+	if got := expandEnvironmentDefaults(cty.ListValEmpty(blockType)); got != nil {
+		t.Errorf("expected no defaults without a block, got %+v", got)
+	}
+
+	got := expandEnvironmentDefaults(cty.ListVal([]cty.Value{
+		cty.ObjectVal(map[string]cty.Value{
+			"wait_timer":          cty.NumberIntVal(30),
+			"can_admins_bypass":   cty.False,
+			"prevent_self_review": cty.NullVal(cty.Bool),
+		}),
+	}))
+	if got == nil {
+		t.Fatal("expected defaults to be read")
+	}
+	if got.WaitTimer == nil || *got.WaitTimer != 30 {
+		t.Errorf("expected a default wait timer of 30, got %v", got.WaitTimer)
+	}
+	if got.CanAdminsBypass == nil || *got.CanAdminsBypass {
+		t.Errorf("expected an explicit false can_admins_bypass default, got %v", got.CanAdminsBypass)
+	}
+	if got.PreventSelfReview != nil {
+		t.Errorf("expected no prevent_self_review default, got %v", *got.PreventSelfReview)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"sync"
+	"time"
 
 	"github.com/google/go-github/v82/github"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -124,7 +126,7 @@ func resourceGithubActionsEnvironmentSecretCreate(ctx context.Context, d *schema
 
 	var publicKey string
 	if len(keyID) == 0 || len(encryptedValue) == 0 {
-		ki, pk, err := getEnvironmentPublicKeyDetails(ctx, meta, repoID, escapedEnvName)
+		ki, pk, err := getEnvironmentPublicKeyDetails(ctx, meta, repoName, repoID, escapedEnvName)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -322,7 +324,7 @@ func resourceGithubActionsEnvironmentSecretUpdate(ctx context.Context, d *schema
 
 	var publicKey string
 	if len(keyID) == 0 || len(encryptedValue) == 0 {
-		ki, pk, err := getEnvironmentPublicKeyDetails(ctx, meta, repoID, escapedEnvName)
+		ki, pk, err := getEnvironmentPublicKeyDetails(ctx, meta, repoName, repoID, escapedEnvName)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -456,7 +458,12 @@ func resourceGithubActionsEnvironmentSecretImport(ctx context.Context, d *schema
 	return []*schema.ResourceData{d}, nil
 }
 
-func getEnvironmentPublicKeyDetails(ctx context.Context, meta *Owner, repoID int, envNameEscaped string) (string, string, error) {
+func getEnvironmentPublicKeyDetails(ctx context.Context, meta *Owner, repoName string, repoID int, envNameEscaped string) (string, string, error) {
+	cacheKey := publicKeyCacheKey(meta.name, repoName, envNameEscaped)
+	if keyID, key, ok := meta.environmentPublicKeys.get(cacheKey); ok {
+		return keyID, key, nil
+	}
+
 	client := meta.v3client
 
 	publicKey, _, err := client.Actions.GetEnvPublicKey(ctx, repoID, envNameEscaped)
@@ -464,5 +471,65 @@ func getEnvironmentPublicKeyDetails(ctx context.Context, meta *Owner, repoID int
 		return "", "", err
 	}
 
+	meta.environmentPublicKeys.set(cacheKey, publicKey.GetKeyID(), publicKey.GetKey())
+
 	return publicKey.GetKeyID(), publicKey.GetKey(), nil
 }
+
+// publicKeyCacheTTL bounds how long a fetched public key is reused, so that a
+// key rotated by GitHub is picked up during long applies.
+const publicKeyCacheTTL = 5 * time.Minute
+
+// publicKeyCache remembers environment public keys so that writing many
+// secrets to the same environment only fetches its key once. Entries are keyed
+// by owner/repo/env, so that they can be dropped without looking up the
+// repository ID.
+type publicKeyCache struct {
+	mu      sync.Mutex
+	entries map[string]publicKeyEntry
+}
+
+type publicKeyEntry struct {
+	keyID     string
+	key       string
+	fetchedAt time.Time
+}
+
+func publicKeyCacheKey(owner, repoName, envNameEscaped string) string {
+	return owner + "/" + repoName + "/" + envNameEscaped
+}
+
+func (c *publicKeyCache) get(key string) (string, string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Since(entry.fetchedAt) > publicKeyCacheTTL {
+		return "", "", false
+	}
+	return entry.keyID, entry.key, true
+}
+
+func (c *publicKeyCache) set(cacheKey, keyID, key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]publicKeyEntry)
+	}
+	c.entries[cacheKey] = publicKeyEntry{keyID: keyID, key: key, fetchedAt: time.Now()}
+}
+
+func (c *publicKeyCache) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+func (c *publicKeyCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = nil
+}
@@ -351,7 +351,7 @@ resource "github_actions_environment_secret" "test" {
 						}
 						repoID := int(repo.GetID())
 
-						keyID, _, err := getEnvironmentPublicKeyDetails(ctx, meta, repoID, escapedEnvName)
+						keyID, _, err := getEnvironmentPublicKeyDetails(ctx, meta, repoName, repoID, escapedEnvName)
 						if err != nil {
 							t.Fatal(err.Error())
 						}
@@ -447,7 +447,7 @@ resource "github_actions_environment_secret" "test" {
 						}
 						repoID := int(repo.GetID())
 
-						keyID, _, err := getEnvironmentPublicKeyDetails(ctx, meta, repoID, escapedEnvName)
+						keyID, _, err := getEnvironmentPublicKeyDetails(ctx, meta, repoName, repoID, escapedEnvName)
 						if err != nil {
 							t.Fatal(err.Error())
 						}
@@ -715,3 +715,30 @@ resource "github_actions_environment_secret" "test" {
 		})
 	})
 }
+
+func TestGetEnvironmentPublicKeyDetailsCachesKey(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:  "/repositories/1234/environments/production/secrets/public-key",
+			ResponseBody: `{"key_id": "key-1", "key": "cHVibGljLWtleQ=="}`,
+			StatusCode:   200,
+		},
+	})
+
+	// Only the first call may reach the API, any further request would fall
+	// off the end of the mock sequence and fail.
+	for i := range 3 {
+		keyID, key, err := getEnvironmentPublicKeyDetails(context.Background(), meta, "test-repo", 1234, "production")
+		if err != nil {
+			t.Fatalf("write %d: unexpected error: %s", i, err)
+		}
+		if keyID != "key-1" || key != "cHVibGljLWtleQ==" {
+			t.Fatalf("write %d: unexpected key %q/%q", i, keyID, key)
+		}
+	}
+
+	meta.environmentPublicKeys.invalidate("test-owner/test-repo/production")
+	if _, _, ok := meta.environmentPublicKeys.get("test-owner/test-repo/production"); ok {
+		t.Error("expected the key to be dropped after invalidation")
+	}
+}
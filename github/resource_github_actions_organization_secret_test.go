@@ -10,6 +10,7 @@ import (
 	"github.com/google/go-github/v82/github"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -653,3 +654,37 @@ resource "github_actions_organization_secret" "test" {
 		})
 	})
 }
+
+func TestGithubActionsOrganizationSecretReadSelectedRepositories(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:  "/orgs/test-owner/actions/secrets/TEST_SECRET",
+			ResponseBody: `{"name": "TEST_SECRET", "visibility": "selected", "created_at": "2024-01-01T00:00:00Z", "updated_at": "2024-01-01T00:00:00Z"}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:  "/orgs/test-owner/actions/secrets/TEST_SECRET/repositories?per_page=100",
+			ResponseBody: `{"total_count": 2, "repositories": [{"id": 1}, {"id": 2}]}`,
+			StatusCode:   200,
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceGithubActionsOrganizationSecret().Schema, map[string]any{
+		"secret_name":             "TEST_SECRET",
+		"plaintext_value":         "foo",
+		"visibility":              "selected",
+		"selected_repository_ids": []any{1},
+	})
+	d.SetId("TEST_SECRET")
+
+	if diags := resourceGithubActionsOrganizationSecretRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// The repository added out of band must end up in state, so that the next
+	// plan proposes to remove it again.
+	ids := d.Get("selected_repository_ids").(*schema.Set)
+	if ids.Len() != 2 || !ids.Contains(1) || !ids.Contains(2) {
+		t.Errorf("expected selected_repository_ids [1 2], got %v", ids.List())
+	}
+}
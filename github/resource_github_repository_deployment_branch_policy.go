@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/google/go-github/v82/github"
@@ -68,7 +69,7 @@ func resourceGithubRepositoryDeploymentBranchPolicyUpdate(d *schema.ResourceData
 		return err
 	}
 
-	_, _, err = client.Repositories.UpdateDeploymentBranchPolicy(ctx, owner, repoName, environmentName, int64(id), &github.DeploymentBranchPolicyRequest{Name: &name})
+	_, _, err = client.Repositories.UpdateDeploymentBranchPolicy(ctx, owner, repoName, url.PathEscape(environmentName), int64(id), &github.DeploymentBranchPolicyRequest{Name: &name})
 	if err != nil {
 		return err
 	}
@@ -88,7 +89,7 @@ func resourceGithubRepositoryDeploymentBranchPolicyCreate(d *schema.ResourceData
 	environmentName := d.Get("environment_name").(string)
 	name := d.Get("name").(string)
 
-	policy, _, err := client.Repositories.CreateDeploymentBranchPolicy(ctx, owner, repoName, environmentName, &github.DeploymentBranchPolicyRequest{Name: &name, Type: github.Ptr("branch")})
+	policy, _, err := client.Repositories.CreateDeploymentBranchPolicy(ctx, owner, repoName, url.PathEscape(environmentName), &github.DeploymentBranchPolicyRequest{Name: &name, Type: github.Ptr("branch")})
 	if err != nil {
 		return err
 	}
@@ -114,7 +115,7 @@ func resourceGithubRepositoryDeploymentBranchPolicyRead(d *schema.ResourceData,
 		return err
 	}
 
-	policy, resp, err := client.Repositories.GetDeploymentBranchPolicy(ctx, owner, repoName, environmentName, int64(id))
+	policy, resp, err := client.Repositories.GetDeploymentBranchPolicy(ctx, owner, repoName, url.PathEscape(environmentName), int64(id))
 	if err != nil {
 		var ghErr *github.ErrorResponse
 		if errors.As(err, &ghErr) {
@@ -160,7 +161,7 @@ func resourceGithubRepositoryDeploymentBranchPolicyDelete(d *schema.ResourceData
 		return err
 	}
 
-	_, err = client.Repositories.DeleteDeploymentBranchPolicy(ctx, owner, repoName, environmentName, int64(id))
+	_, err = client.Repositories.DeleteDeploymentBranchPolicy(ctx, owner, repoName, url.PathEscape(environmentName), int64(id))
 	if err != nil {
 		return err
 	}
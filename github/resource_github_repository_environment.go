@@ -3,11 +3,16 @@ package github
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"net/url"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/google/go-github/v82/github"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -22,6 +27,11 @@ func resourceGithubRepositoryEnvironment() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceGithubRepositoryEnvironmentImport,
 		},
+		CustomizeDiff: resourceGithubRepositoryEnvironmentDiff,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validateEnvironmentPreventSelfReview,
+			validateEnvironmentCustomBranchPolicies,
+		},
 		Schema: map[string]*schema.Schema{
 			"repository": {
 				Type:        schema.TypeString,
@@ -36,10 +46,11 @@ func resourceGithubRepositoryEnvironment() *schema.Resource {
 				Description: "The name of the environment.",
 			},
 			"can_admins_bypass": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     true,
-				Description: "Can Admins bypass deployment protections",
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          true,
+				DiffSuppressFunc: suppressCanAdminsBypassWithoutProtectionRules,
+				Description:      "Can Admins bypass deployment protections",
 			},
 			"prevent_self_review": {
 				Type:        schema.TypeBool,
@@ -75,6 +86,23 @@ func resourceGithubRepositoryEnvironment() *schema.Resource {
 					},
 				},
 			},
+			"strict_reviewers": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Fail the apply instead of warning when GitHub does not accept some of the configured reviewers.",
+			},
+			"defaulted_settings": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The settings that were filled in from the provider's environment_defaults because the configuration omits them.",
+			},
+			"environment_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL of the environment settings page.",
+			},
 			"deployment_branch_policy": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -84,14 +112,22 @@ func resourceGithubRepositoryEnvironment() *schema.Resource {
 					Schema: map[string]*schema.Schema{
 						"protected_branches": {
 							Type:        schema.TypeBool,
-							Required:    true,
+							Optional:    true,
+							Default:     false,
 							Description: "Whether only branches with branch protection rules can deploy to this environment.",
 						},
 						"custom_branch_policies": {
 							Type:        schema.TypeBool,
-							Required:    true,
+							Optional:    true,
+							Default:     false,
 							Description: "Whether only branches that match the specified name patterns can deploy to this environment.",
 						},
+						"all_branches": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether all branches can deploy to this environment. Resets any restrictive policy; mutually exclusive with 'protected_branches' and 'custom_branch_policies'.",
+						},
 					},
 				},
 			},
@@ -105,20 +141,69 @@ func resourceGithubRepositoryEnvironmentCreate(ctx context.Context, d *schema.Re
 
 	repoName := d.Get("repository").(string)
 	envName := d.Get("environment").(string)
-	updateData := createUpdateEnvironmentData(d)
+	updateData := createUpdateEnvironmentData(d, meta.(*Owner).environmentDefaults)
 
-	_, _, err := client.Repositories.CreateUpdateEnvironment(ctx, owner, repoName, url.PathEscape(envName), &updateData)
+	id, err := buildID(repoName, escapeIDPart(envName))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	id, err := buildID(repoName, escapeIDPart(envName))
+	// On a dry run the planned values are kept in state as if the environment
+	// had been created.
+	if skipForDryRun(meta.(*Owner), fmt.Sprintf("creating environment %s of repository %s/%s", envName, owner, repoName), updateData) {
+		d.SetId(id)
+		_ = d.Set("defaulted_settings", environmentDefaultedSettings(d, meta.(*Owner).environmentDefaults))
+		return nil
+	}
+
+	// Creating an environment is an upsert, so an environment that existed
+	// before is restored rather than removed when its reviewers are rejected.
+	strict := d.Get("strict_reviewers").(bool)
+	var prev *github.Environment
+	if strict {
+		existing, _, err := client.Repositories.GetEnvironment(ctx, owner, repoName, url.PathEscape(envName))
+		var ghErr *github.ErrorResponse
+		switch {
+		case err == nil:
+			prev = existing
+		case !errors.As(err, &ghErr) || ghErr.Response.StatusCode != http.StatusNotFound:
+			return environmentReadErrorDiagnostics(err, repoName, envName)
+		}
+	}
+
+	env, _, err := client.Repositories.CreateUpdateEnvironment(ctx, owner, repoName, url.PathEscape(envName), &updateData)
 	if err != nil {
-		return diag.FromErr(err)
+		return environmentWriteErrorDiagnostics(err, repoName, envName, updateData.WaitTimer)
 	}
 	d.SetId(id)
+	_ = d.Set("defaulted_settings", environmentDefaultedSettings(d, meta.(*Owner).environmentDefaults))
 
-	return nil
+	diags := checkDroppedReviewers(updateData.Reviewers, env, strict)
+	if diags.HasError() {
+		d.SetId("")
+		if prev != nil {
+			// The environment and its secrets and variables predate this
+			// resource, so it is put back the way it was rather than deleted.
+			log.Printf("[DEBUG] Restoring existing repository environment %s after GitHub did not accept all of its reviewers", id)
+			restoreData := createUpdateEnvironmentFromEnvironment(prev)
+			if _, _, err := client.Repositories.CreateUpdateEnvironment(ctx, owner, repoName, url.PathEscape(envName), &restoreData); err != nil {
+				return append(diags, diag.Errorf("failed to restore the previous configuration of environment %s: %s", envName, err)...)
+			}
+			return diags
+		}
+
+		// GitHub created the environment without some of its reviewers, remove
+		// it again instead of leaving a half-configured environment behind.
+		log.Printf("[DEBUG] Deleting repository environment %s after GitHub did not accept all of its reviewers", id)
+		if _, err := client.Repositories.DeleteEnvironment(ctx, owner, repoName, url.PathEscape(envName)); err != nil {
+			return append(diags, diag.Errorf("failed to delete partially created environment %s: %s", envName, err)...)
+		}
+		return diags
+	}
+	diags = append(diags, checkCanAdminsBypass(updateData.CanAdminsBypass, env)...)
+	diags = append(diags, warnOnUnprotectedRepository(ctx, meta.(*Owner), repoName, updateData.DeploymentBranchPolicy)...)
+	diags = append(diags, warnOnDisabledActions(ctx, meta.(*Owner), repoName)...)
+	return append(diags, resourceGithubRepositoryEnvironmentRead(ctx, d, meta)...)
 }
 
 func resourceGithubRepositoryEnvironmentRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
@@ -130,7 +215,7 @@ func resourceGithubRepositoryEnvironmentRead(ctx context.Context, d *schema.Reso
 		return diag.FromErr(err)
 	}
 
-	envName := unescapeIDPart(envNamePart)
+	envName := unescapeIDPartFromState(d, "environment", envNamePart)
 
 
 	// ---------- manual insert start ----------
@@ -143,12 +228,8 @@ func resourceGithubRepositoryEnvironmentRead(ctx context.Context, d *schema.Reso
 		return nil
 	}
 
-	// 2️⃣ Check if repository is archived
-	if repo.GetArchived() {
-		log.Printf("[INFO] Removing repository environment %s from state because repository %s is archived", d.Id(), repoName)
-		d.SetId("") // delete from state
-		return nil
-	}
+	// Environments of archived repositories can still be read and deleted, so
+	// they are kept in state rather than left behind unmanaged.
 
 	// ---------- manual insert end ----------
 
@@ -163,13 +244,18 @@ func resourceGithubRepositoryEnvironmentRead(ctx context.Context, d *schema.Reso
 				return nil
 			}
 		}
-		return diag.FromErr(err)
+		return environmentReadErrorDiagnostics(err, repoName, envName)
 	}
 
+	restoreOmittedDefaults := keepOmittedEnvironmentDefaults(d, meta.(*Owner).environmentDefaults)
+
 	_ = d.Set("repository", repoName)
 	_ = d.Set("environment", envName)
 	_ = d.Set("wait_timer", nil)
-	_ = d.Set("can_admins_bypass", env.CanAdminsBypass)
+	if env.CanAdminsBypass != nil {
+		_ = d.Set("can_admins_bypass", env.CanAdminsBypass)
+	}
+	_ = d.Set("environment_url", environmentURL(repo.GetHTMLURL(), env.GetID()))
 
 	for _, pr := range env.ProtectionRules {
 		switch *pr.Type {
@@ -179,21 +265,7 @@ func resourceGithubRepositoryEnvironmentRead(ctx context.Context, d *schema.Reso
 			}
 
 		case "required_reviewers":
-			teams := make([]int64, 0)
-			users := make([]int64, 0)
-
-			for _, r := range pr.Reviewers {
-				switch *r.Type {
-				case "Team":
-					if r.Reviewer.(*github.Team).ID != nil {
-						teams = append(teams, *r.Reviewer.(*github.Team).ID)
-					}
-				case "User":
-					if r.Reviewer.(*github.User).ID != nil {
-						users = append(users, *r.Reviewer.(*github.User).ID)
-					}
-				}
-			}
+			teams, users := flattenEnvironmentReviewerIDs(pr.Reviewers, false)
 			if err = d.Set("reviewers", []any{
 				map[string]any{
 					"teams": teams,
@@ -209,6 +281,8 @@ func resourceGithubRepositoryEnvironmentRead(ctx context.Context, d *schema.Reso
 		}
 	}
 
+	restoreOmittedDefaults()
+
 	if env.DeploymentBranchPolicy != nil {
 		if err = d.Set("deployment_branch_policy", []any{
 			map[string]any{
@@ -218,6 +292,18 @@ func resourceGithubRepositoryEnvironmentRead(ctx context.Context, d *schema.Reso
 		}); err != nil {
 			return diag.FromErr(err)
 		}
+	} else if d.Get("deployment_branch_policy.0.all_branches").(bool) {
+		// GitHub reports no policy when all branches can deploy, keep the
+		// explicit form from the configuration.
+		if err = d.Set("deployment_branch_policy", []any{
+			map[string]any{
+				"protected_branches":     false,
+				"custom_branch_policies": false,
+				"all_branches":           true,
+			},
+		}); err != nil {
+			return diag.FromErr(err)
+		}
 	} else {
 		_ = d.Set("deployment_branch_policy", []any{})
 	}
@@ -225,13 +311,83 @@ func resourceGithubRepositoryEnvironmentRead(ctx context.Context, d *schema.Reso
 	return nil
 }
 
+// environmentReadErrorDiagnostics turns a failed environment read into
+// diagnostics. GitHub answers with a 403 both when the token lacks permission
+// and when a rate limit is hit. Rate limits are waited out by the transport,
+// so one that still surfaces here is returned as is, while a missing
+// permission gets an explanation instead of the raw API error.
+func environmentReadErrorDiagnostics(err error, repoName, envName string) diag.Diagnostics {
+	var rateLimitErr *github.RateLimitError
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &rateLimitErr) || errors.As(err, &abuseErr) {
+		return diag.FromErr(err)
+	}
+
+	var ghErr *github.ErrorResponse
+	if errors.As(err, &ghErr) && ghErr.Response.StatusCode == http.StatusForbidden {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  "Missing permission to read repository environment",
+				Detail: fmt.Sprintf("GitHub denied access to environment %s of repository %s: %s. "+
+					"Tokens need read access to the repository's environments; for a GitHub App this is the \"Environments\" repository permission, "+
+					"and the app must be installed on the repository.", envName, repoName, ghErr.Message),
+			},
+		}
+	}
+
+	return diag.FromErr(err)
+}
+
+// environmentWriteErrorDiagnostics explains GitHub rejecting the wait timer of
+// an environment, whose limit depends on the plan of the repository owner.
+func environmentWriteErrorDiagnostics(err error, repoName, envName string, waitTimer *int) diag.Diagnostics {
+	var ghErr *github.ErrorResponse
+	if waitTimer == nil || !errors.As(err, &ghErr) || ghErr.Response.StatusCode != http.StatusUnprocessableEntity || !isWaitTimerError(ghErr) {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  "GitHub rejected the wait timer of the environment",
+			Detail: fmt.Sprintf("GitHub did not accept a wait_timer of %d minutes for environment %s of repository %s: %s. "+
+				"Wait timers are limited to 43200 minutes, and the plan of the repository owner may allow less or none at all "+
+				"for private repositories. Set max_wait_timer on the provider to catch this at plan time.", *waitTimer, envName, repoName, ghErr.Message),
+			AttributePath: cty.GetAttrPath("wait_timer"),
+		},
+	}
+}
+
+func isWaitTimerError(ghErr *github.ErrorResponse) bool {
+	mentionsWaitTimer := func(s string) bool {
+		s = strings.ToLower(s)
+		return strings.Contains(s, "wait_timer") || strings.Contains(s, "wait timer")
+	}
+
+	if mentionsWaitTimer(ghErr.Message) {
+		return true
+	}
+	for _, e := range ghErr.Errors {
+		if mentionsWaitTimer(e.Field) || mentionsWaitTimer(e.Message) {
+			return true
+		}
+	}
+	return false
+}
+
 func resourceGithubRepositoryEnvironmentUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 
 	repoName := d.Get("repository").(string)
 	envName := d.Get("environment").(string)
-	updateData := createUpdateEnvironmentData(d)
+	updateData := createUpdateEnvironmentData(d, meta.(*Owner).environmentDefaults)
+
+	if skipForDryRun(meta.(*Owner), fmt.Sprintf("updating environment %s of repository %s/%s", envName, owner, repoName), updateData) {
+		_ = d.Set("defaulted_settings", environmentDefaultedSettings(d, meta.(*Owner).environmentDefaults))
+		return nil
+	}
 
 	// ---------- manual insert start ----------
 
@@ -245,16 +401,14 @@ func resourceGithubRepositoryEnvironmentUpdate(ctx context.Context, d *schema.Re
 
 	// Check if repository is archived
 	if repo.GetArchived() {
-		log.Printf("[INFO] Removing repository environment %s from state because repository %s is archived", repoName, envName)
-		d.SetId("") // delete from state
-		return nil
+		return diag.Errorf("cannot update environment %s because repository %s is archived", envName, repoName)
 	}
 
 	// ---------- manual insert end ----------
 
-	_, _, err := client.Repositories.CreateUpdateEnvironment(ctx, owner, repoName, url.PathEscape(envName), &updateData)
+	env, _, err := client.Repositories.CreateUpdateEnvironment(ctx, owner, repoName, url.PathEscape(envName), &updateData)
 	if err != nil {
-		return diag.FromErr(err)
+		return environmentWriteErrorDiagnostics(err, repoName, envName, updateData.WaitTimer)
 	}
 
 	id, err := buildID(repoName, escapeIDPart(envName))
@@ -262,8 +416,14 @@ func resourceGithubRepositoryEnvironmentUpdate(ctx context.Context, d *schema.Re
 		return diag.FromErr(err)
 	}
 	d.SetId(id)
+	_ = d.Set("defaulted_settings", environmentDefaultedSettings(d, meta.(*Owner).environmentDefaults))
 
-	return nil
+	diags := checkDroppedReviewers(updateData.Reviewers, env, d.Get("strict_reviewers").(bool))
+	if diags.HasError() {
+		return diags
+	}
+	diags = append(diags, checkCanAdminsBypass(updateData.CanAdminsBypass, env)...)
+	return append(diags, warnOnUnprotectedRepository(ctx, meta.(*Owner), repoName, updateData.DeploymentBranchPolicy)...)
 }
 
 func resourceGithubRepositoryEnvironmentDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
@@ -275,27 +435,31 @@ func resourceGithubRepositoryEnvironmentDelete(ctx context.Context, d *schema.Re
 		return diag.FromErr(err)
 	}
 
-	envName := unescapeIDPart(envNamePart)
+	envName := unescapeIDPartFromState(d, "environment", envNamePart)
+
+	if skipForDryRun(meta.(*Owner), fmt.Sprintf("deleting environment %s of repository %s/%s", envName, owner, repoName), nil) {
+		return nil
+	}
 
 	// ---------- manual insert start ----------
 
 	// Check if repository exists
-	repo, resp, _ := client.Repositories.Get(ctx, owner, repoName)
+	_, resp, _ := client.Repositories.Get(ctx, owner, repoName)
 	if resp == nil || resp.StatusCode == 404 {
 		log.Printf("[INFO] Removing repository environment %s from state because repository %s does not exist", repoName, envName)
 		d.SetId("") // delete from state
 		return nil
 	}
 
-	// Check if repository is archived
-	if repo.GetArchived() {
-		log.Printf("[INFO] Removing repository environment %s from state because repository %s is archived", repoName, envName)
-		d.SetId("") // delete from state
-		return nil
-	}
+	// Archived repositories keep their environments and GitHub still allows
+	// deleting them, so fall through to a real delete rather than dropping the
+	// environment from state and leaving it behind unmanaged.
 
 	// ---------- manual insert end ----------
 
+	// A recreated environment gets a new public key.
+	meta.(*Owner).environmentPublicKeys.invalidate(publicKeyCacheKey(owner, repoName, url.PathEscape(envName)))
+
 	_, err = client.Repositories.DeleteEnvironment(ctx, owner, repoName, url.PathEscape(envName))
 	if err != nil {
 		return diag.FromErr(deleteResourceOn404AndSwallow304OtherwiseReturnError(err, d, "environment (%s)", envName))
@@ -310,17 +474,428 @@ func resourceGithubRepositoryEnvironmentImport(ctx context.Context, d *schema.Re
 		return nil, err
 	}
 
+	envName := unescapeIDPart(envNamePart)
+
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+
+	// The repository may be qualified with its owner, which has to be the one
+	// the provider is configured for since every request is made against it.
+	if idOwner, name, ok := strings.Cut(repoName, "/"); ok {
+		if !strings.EqualFold(idOwner, owner) {
+			return nil, fmt.Errorf("repository %s belongs to %s, but the provider is configured for owner %s; import it with a provider configured for %s", name, idOwner, owner, idOwner)
+		}
+		repoName = name
+
+		id, err := buildID(repoName, envNamePart)
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(id)
+	}
+
+	_, _, err = client.Repositories.GetEnvironment(ctx, owner, repoName, url.PathEscape(envName))
+	if err != nil {
+		var ghErr *github.ErrorResponse
+		if errors.As(err, &ghErr) && ghErr.Response.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("environment %s does not exist in repository %s; nothing to import", envName, repoName)
+		}
+		return nil, err
+	}
+
 	if err := d.Set("repository", repoName); err != nil {
 		return nil, err
 	}
-	if err := d.Set("environment", unescapeIDPart(envNamePart)); err != nil {
+	if err := d.Set("environment", envName); err != nil {
 		return nil, err
 	}
 
 	return []*schema.ResourceData{d}, nil
 }
 
-func createUpdateEnvironmentData(d *schema.ResourceData) github.CreateUpdateEnvironment {
+// validateEnvironmentPreventSelfReview warns when prevent_self_review is set
+// without any reviewers, since GitHub only applies it to required reviewers.
+func validateEnvironmentPreventSelfReview(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	if !req.RawConfig.IsKnown() || req.RawConfig.IsNull() {
+		return
+	}
+
+	preventSelfReview := req.RawConfig.GetAttr("prevent_self_review")
+	if !preventSelfReview.IsKnown() || preventSelfReview.IsNull() || preventSelfReview.False() {
+		return
+	}
+
+	reviewers := req.RawConfig.GetAttr("reviewers")
+	if !reviewers.IsKnown() {
+		return
+	}
+	if !reviewers.IsNull() {
+		for it := reviewers.ElementIterator(); it.Next(); {
+			_, block := it.Element()
+			if !block.IsKnown() || block.IsNull() {
+				continue
+			}
+			for _, key := range []string{"teams", "users"} {
+				ids := block.GetAttr(key)
+				if !ids.IsKnown() || (!ids.IsNull() && ids.LengthInt() > 0) {
+					return
+				}
+			}
+		}
+	}
+
+	resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+		Severity:      diag.Warning,
+		Summary:       "prevent_self_review has no effect without reviewers",
+		Detail:        "GitHub only prevents self-review for environments with required reviewers. Configure reviewers, or manage them with github_repository_environment_reviewers and ignore changes to prevent_self_review as documented.",
+		AttributePath: cty.GetAttrPath("prevent_self_review"),
+	})
+}
+
+// validateEnvironmentCustomBranchPolicies warns when custom_branch_policies is
+// enabled, since nothing can deploy to the environment until patterns are
+// added by the separate deployment policy resources, which the configuration
+// of this resource cannot see.
+func validateEnvironmentCustomBranchPolicies(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	if !req.RawConfig.IsKnown() || req.RawConfig.IsNull() {
+		return
+	}
+
+	policy := req.RawConfig.GetAttr("deployment_branch_policy")
+	if !policy.IsKnown() || policy.IsNull() {
+		return
+	}
+	for it := policy.ElementIterator(); it.Next(); {
+		_, block := it.Element()
+		if !block.IsKnown() || block.IsNull() {
+			continue
+		}
+		custom := block.GetAttr("custom_branch_policies")
+		if !custom.IsKnown() || custom.IsNull() || custom.False() {
+			continue
+		}
+
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "custom_branch_policies requires deployment policies",
+			Detail:        "With custom_branch_policies enabled, only branches and tags matching a deployment policy can deploy to this environment, and a new environment has none. Declare the patterns with github_repository_environment_deployment_policy or github_repository_environment_deployment_policies.",
+			AttributePath: cty.GetAttrPath("deployment_branch_policy"),
+		})
+		return
+	}
+}
+
+func resourceGithubRepositoryEnvironmentDiff(ctx context.Context, diff *schema.ResourceDiff, m any) error {
+	if v, ok := diff.GetOk("deployment_branch_policy"); ok {
+		if policy, ok := expandDeploymentBranchPolicy(v); ok {
+			if err := validateEnvironmentBranchPolicy(policy); err != nil {
+				return err
+			}
+		}
+	}
+
+	meta := m.(*Owner)
+	if diff.NewValueKnown("wait_timer") {
+		if err := validateEnvironmentWaitTimer(diff.Get("wait_timer").(int), meta.maxWaitTimer); err != nil {
+			return err
+		}
+	}
+
+	if !meta.validateEnvironmentReviewers || !meta.IsOrganization {
+		return nil
+	}
+
+	if !diff.NewValueKnown("reviewers") {
+		return nil
+	}
+
+	v, ok := diff.GetOk("reviewers")
+	if !ok {
+		return nil
+	}
+
+	return validateEnvironmentReviewerTeams(ctx, meta, expandReviewers(v, "teams"))
+}
+
+// validateEnvironmentWaitTimer checks a wait timer against the max_wait_timer
+// configured on the provider, where 0 means GitHub's own limit applies.
+func validateEnvironmentWaitTimer(waitTimer, maxWaitTimer int) error {
+	if maxWaitTimer > 0 && waitTimer > maxWaitTimer {
+		return fmt.Errorf("wait_timer of %d minutes exceeds the max_wait_timer of %d minutes configured on the provider", waitTimer, maxWaitTimer)
+	}
+	return nil
+}
+
+// validateEnvironmentBranchPolicy checks that all_branches is not combined
+// with either of the restrictive policy types.
+func validateEnvironmentBranchPolicy(policy map[string]any) error {
+	if policy["all_branches"].(bool) && (policy["protected_branches"].(bool) || policy["custom_branch_policies"].(bool)) {
+		return fmt.Errorf("deployment_branch_policy: all_branches cannot be combined with protected_branches or custom_branch_policies")
+	}
+	return nil
+}
+
+// validateEnvironmentReviewerTeams checks that every team ID belongs to the
+// configured organization, since GitHub silently drops foreign team reviewers.
+func validateEnvironmentReviewerTeams(ctx context.Context, meta *Owner, teamIDs []int64) error {
+	client := meta.v3client
+
+	for _, teamID := range teamIDs {
+		_, _, err := client.Teams.GetTeamByID(ctx, meta.id, teamID)
+		if err != nil {
+			var ghErr *github.ErrorResponse
+			if errors.As(err, &ghErr) && ghErr.Response.StatusCode == http.StatusNotFound {
+				return fmt.Errorf("reviewer team %d does not belong to organization %s", teamID, meta.name)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// environmentURL builds the URL of an environment's settings page from the
+// web URL of its repository, which already reflects the GitHub host in use.
+func environmentURL(repoHTMLURL string, envID int64) string {
+	if repoHTMLURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/settings/environments/%d", strings.TrimSuffix(repoHTMLURL, "/"), envID)
+}
+
+// warnOnUnprotectedRepository warns when an environment only allows protected
+// branches to deploy but the repository has no protected branches, in which
+// case nothing can deploy to it.
+func warnOnUnprotectedRepository(ctx context.Context, meta *Owner, repoName string, policy *github.BranchPolicy) diag.Diagnostics {
+	if !policy.GetProtectedBranches() {
+		return nil
+	}
+
+	cacheKey := meta.name + "/" + repoName
+	hasProtectedBranches, ok := meta.protectedBranches.get(cacheKey)
+	if !ok {
+		branches, _, err := meta.v3client.Repositories.ListBranches(ctx, meta.name, repoName, &github.BranchListOptions{
+			Protected:   github.Ptr(true),
+			ListOptions: github.ListOptions{PerPage: 1},
+		})
+		if err != nil {
+			log.Printf("[DEBUG] Unable to list protected branches of repository %s: %s", repoName, err)
+			return nil
+		}
+
+		hasProtectedBranches = len(branches) > 0
+		meta.protectedBranches.set(cacheKey, hasProtectedBranches)
+	}
+
+	if hasProtectedBranches {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity:      diag.Warning,
+			Summary:       "Repository has no protected branches",
+			Detail:        fmt.Sprintf("The deployment branch policy only allows protected branches, but repository %s has no protected branches, so no branch can deploy to this environment.", repoName),
+			AttributePath: cty.GetAttrPath("deployment_branch_policy"),
+		},
+	}
+}
+
+// checkDroppedReviewers compares the reviewers sent to GitHub with the ones on
+// the environment it returned. GitHub silently drops reviewers that are not
+// eligible, e.g. users without access to the repository, so report the dropped
+// ones as a warning, or as an error in strict mode.
+func checkDroppedReviewers(requested []*github.EnvReviewers, env *github.Environment, strict bool) diag.Diagnostics {
+	accepted := make(map[string]bool)
+	for _, pr := range env.ProtectionRules {
+		if pr.GetType() != "required_reviewers" {
+			continue
+		}
+		teams, users := flattenEnvironmentReviewerIDs(pr.Reviewers, false)
+		for _, id := range teams {
+			accepted[fmt.Sprintf("Team %d", id)] = true
+		}
+		for _, id := range users {
+			accepted[fmt.Sprintf("User %d", id)] = true
+		}
+	}
+
+	dropped := make([]string, 0)
+	for _, r := range requested {
+		key := fmt.Sprintf("%s %d", r.GetType(), r.GetID())
+		if !accepted[key] {
+			dropped = append(dropped, strings.ToLower(key))
+		}
+	}
+	if len(dropped) == 0 {
+		return nil
+	}
+
+	severity := diag.Warning
+	if strict {
+		severity = diag.Error
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity:      severity,
+			Summary:       "GitHub did not accept all environment reviewers",
+			Detail:        fmt.Sprintf("The following reviewers were dropped by GitHub, most likely because they do not have access to the repository: %s.", strings.Join(dropped, ", ")),
+			AttributePath: cty.GetAttrPath("reviewers"),
+		},
+	}
+}
+
+// checkCanAdminsBypass warns when the environment returned by GitHub does not
+// reflect the requested can_admins_bypass.
+func checkCanAdminsBypass(requested *bool, env *github.Environment) diag.Diagnostics {
+	if requested == nil || env.CanAdminsBypass == nil || *requested == env.GetCanAdminsBypass() {
+		return nil
+	}
+
+	detail := fmt.Sprintf("GitHub stored can_admins_bypass = %t instead of the configured %t.", env.GetCanAdminsBypass(), *requested)
+	if len(env.ProtectionRules) == 0 {
+		detail += " The environment has no protection rules, so there is nothing for admins to bypass and the setting has no effect until protection rules are added."
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity:      diag.Warning,
+			Summary:       "GitHub did not apply can_admins_bypass",
+			Detail:        detail,
+			AttributePath: cty.GetAttrPath("can_admins_bypass"),
+		},
+	}
+}
+
+// suppressCanAdminsBypassWithoutProtectionRules hides differences in
+// can_admins_bypass while the environment has no protection rules. There is
+// nothing to bypass then and GitHub does not always store the setting, so the
+// difference has no effect. Only the configuration counts, since protection
+// rules that exist in GitHub alone are removed by the next apply.
+func suppressCanAdminsBypassWithoutProtectionRules(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		return false
+	}
+	return !hasConfiguredProtectionRules(raw)
+}
+
+// hasConfiguredProtectionRules reports whether the raw configuration of an
+// environment declares a wait timer, reviewers or a branch policy other than
+// all_branches. Values that are not known yet count as protection rules.
+func hasConfiguredProtectionRules(raw cty.Value) bool {
+	waitTimer := raw.GetAttr("wait_timer")
+	if !waitTimer.IsKnown() || (!waitTimer.IsNull() && waitTimer.AsBigFloat().Sign() > 0) {
+		return true
+	}
+
+	reviewers := raw.GetAttr("reviewers")
+	if !reviewers.IsKnown() {
+		return true
+	}
+	if !reviewers.IsNull() {
+		for it := reviewers.ElementIterator(); it.Next(); {
+			_, block := it.Element()
+			if !block.IsKnown() {
+				return true
+			}
+			if block.IsNull() {
+				continue
+			}
+			for _, key := range []string{"teams", "users"} {
+				ids := block.GetAttr(key)
+				if !ids.IsWhollyKnown() || (!ids.IsNull() && ids.LengthInt() > 0) {
+					return true
+				}
+			}
+		}
+	}
+
+	policy := raw.GetAttr("deployment_branch_policy")
+	if !policy.IsKnown() {
+		return true
+	}
+	if !policy.IsNull() {
+		for it := policy.ElementIterator(); it.Next(); {
+			_, block := it.Element()
+			if !block.IsKnown() {
+				return true
+			}
+			if block.IsNull() {
+				continue
+			}
+			allBranches := block.GetAttr("all_branches")
+			if !allBranches.IsKnown() || allBranches.IsNull() || allBranches.False() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// warnOnDisabledActions warns when an environment is created on a repository
+// with GitHub Actions disabled, where no workflow can ever reference it. The
+// check costs an extra request, so it only runs when warn_on_disabled_actions
+// is set.
+func warnOnDisabledActions(ctx context.Context, meta *Owner, repoName string) diag.Diagnostics {
+	if !meta.warnOnDisabledActions {
+		return nil
+	}
+
+	permissions, _, err := meta.v3client.Repositories.GetActionsPermissions(ctx, meta.name, repoName)
+	if err != nil {
+		log.Printf("[DEBUG] Unable to read the actions permissions of repository %s: %s", repoName, err)
+		return nil
+	}
+
+	if permissions.GetEnabled() {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "GitHub Actions is disabled on the repository",
+			Detail:   fmt.Sprintf("GitHub Actions is disabled on repository %s, so no workflow can deploy to this environment until it is enabled.", repoName),
+		},
+	}
+}
+
+// protectedBranchCache remembers whether a repository has any protected
+// branches, so that applying several environments of the same repository only
+// looks it up once.
+type protectedBranchCache struct {
+	mu      sync.Mutex
+	entries map[string]bool
+}
+
+func (c *protectedBranchCache) get(key string) (bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	protected, ok := c.entries[key]
+	return protected, ok
+}
+
+func (c *protectedBranchCache) set(key string, protected bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]bool)
+	}
+	c.entries[key] = protected
+}
+
+func (c *protectedBranchCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = nil
+}
+
+func createUpdateEnvironmentData(d *schema.ResourceData, defaults *EnvironmentDefaults) github.CreateUpdateEnvironment {
 	data := github.CreateUpdateEnvironment{}
 
 	if v, ok := d.GetOk("wait_timer"); ok {
@@ -331,44 +906,189 @@ func createUpdateEnvironmentData(d *schema.ResourceData) github.CreateUpdateEnvi
 
 	data.PreventSelfReview = github.Ptr(d.Get("prevent_self_review").(bool))
 
-	if v, ok := d.GetOk("reviewers"); ok {
-		envReviewers := make([]*github.EnvReviewers, 0)
+	if defaults != nil {
+		if defaults.WaitTimer != nil && isOmittedFromConfig(d, "wait_timer") {
+			data.WaitTimer = defaults.WaitTimer
+		}
+		if defaults.CanAdminsBypass != nil && isOmittedFromConfig(d, "can_admins_bypass") {
+			data.CanAdminsBypass = defaults.CanAdminsBypass
+		}
+		if defaults.PreventSelfReview != nil && isOmittedFromConfig(d, "prevent_self_review") {
+			data.PreventSelfReview = defaults.PreventSelfReview
+		}
+	}
 
-		for _, team := range expandReviewers(v, "teams") {
-			envReviewers = append(envReviewers, &github.EnvReviewers{
-				Type: github.Ptr("Team"),
-				ID:   github.Ptr(team),
-			})
+	// Reviewers are always sent, so that removing them from the configuration
+	// clears them in GitHub.
+	reviewers := d.Get("reviewers")
+	data.Reviewers = buildEnvironmentReviewers(expandReviewers(reviewers, "teams"), expandReviewers(reviewers, "users"))
+
+	// Leaving the policy nil lets all branches deploy. This covers all_branches,
+	// an empty block and an empty list.
+	if v, ok := d.GetOk("deployment_branch_policy"); ok {
+		if policy, ok := expandDeploymentBranchPolicy(v); ok && !policy["all_branches"].(bool) {
+			data.DeploymentBranchPolicy = &github.BranchPolicy{
+				ProtectedBranches:    github.Ptr(policy["protected_branches"].(bool)),
+				CustomBranchPolicies: github.Ptr(policy["custom_branch_policies"].(bool)),
+			}
 		}
+	}
+
+	return data
+}
+
+// EnvironmentDefaults holds the provider-level environment_defaults, applied
+// to every github_repository_environment that omits the corresponding setting.
+// A nil field has no default.
+type EnvironmentDefaults struct {
+	WaitTimer         *int
+	CanAdminsBypass   *bool
+	PreventSelfReview *bool
+}
 
-		for _, user := range expandReviewers(v, "users") {
-			envReviewers = append(envReviewers, &github.EnvReviewers{
-				Type: github.Ptr("User"),
-				ID:   github.Ptr(user),
-			})
+// isOmittedFromConfig reports whether the given top-level attribute is absent
+// from the resource configuration, as opposed to set to its zero value.
+func isOmittedFromConfig(d *schema.ResourceData, key string) bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		return false
+	}
+	return raw.GetAttr(key).IsNull()
+}
+
+// environmentDefaultedSettings lists the settings that take their value from
+// environment_defaults because the configuration omits them. It is recorded
+// when writing the environment, since only then is the configuration known.
+func environmentDefaultedSettings(d *schema.ResourceData, defaults *EnvironmentDefaults) []string {
+	settings := make([]string, 0)
+	if defaults == nil {
+		return settings
+	}
+
+	if defaults.WaitTimer != nil && isOmittedFromConfig(d, "wait_timer") {
+		settings = append(settings, "wait_timer")
+	}
+	if defaults.CanAdminsBypass != nil && isOmittedFromConfig(d, "can_admins_bypass") {
+		settings = append(settings, "can_admins_bypass")
+	}
+	if defaults.PreventSelfReview != nil && isOmittedFromConfig(d, "prevent_self_review") {
+		settings = append(settings, "prevent_self_review")
+	}
+	return settings
+}
+
+// keepOmittedEnvironmentDefaults restores the omitted state value of settings
+// recorded in defaulted_settings, as long as GitHub still reports the default.
+// Otherwise the configuration, which omits them, would never match the state.
+// It has to be called with the prior state values, before Read overwrites
+// them.
+func keepOmittedEnvironmentDefaults(d *schema.ResourceData, defaults *EnvironmentDefaults) func() {
+	if defaults == nil {
+		return func() {}
+	}
+
+	type prior struct {
+		state        any
+		defaultValue any
+	}
+	priors := make(map[string]prior)
+
+	// A setting omitted from the configuration is stored with the resource
+	// schema's own default.
+	defaulted := d.Get("defaulted_settings").(*schema.Set)
+	if defaults.WaitTimer != nil && defaulted.Contains("wait_timer") {
+		priors["wait_timer"] = prior{state: nil, defaultValue: *defaults.WaitTimer}
+	}
+	if defaults.CanAdminsBypass != nil && defaulted.Contains("can_admins_bypass") {
+		priors["can_admins_bypass"] = prior{state: true, defaultValue: *defaults.CanAdminsBypass}
+	}
+	if defaults.PreventSelfReview != nil && defaulted.Contains("prevent_self_review") {
+		priors["prevent_self_review"] = prior{state: false, defaultValue: *defaults.PreventSelfReview}
+	}
+
+	return func() {
+		for key, p := range priors {
+			if d.Get(key) == p.defaultValue {
+				_ = d.Set(key, p.state)
+			}
 		}
+	}
+}
 
-		data.Reviewers = envReviewers
+// expandDeploymentBranchPolicy returns the single deployment_branch_policy
+// block, if any. An empty list, e.g. from a conditional, has no element and an
+// empty block has a nil one since none of its fields are required.
+func expandDeploymentBranchPolicy(v any) (map[string]any, bool) {
+	l, ok := v.([]any)
+	if !ok || len(l) == 0 {
+		return nil, false
 	}
+	policy, ok := l[0].(map[string]any)
+	return policy, ok
+}
 
-	if v, ok := d.GetOk("deployment_branch_policy"); ok {
-		policy := v.([]any)[0].(map[string]any)
-		data.DeploymentBranchPolicy = &github.BranchPolicy{
-			ProtectedBranches:    github.Ptr(policy["protected_branches"].(bool)),
-			CustomBranchPolicies: github.Ptr(policy["custom_branch_policies"].(bool)),
+// createUpdateEnvironmentFromEnvironment builds the payload that re-applies the
+// current configuration of an environment, so that callers owning only part of
+// it can patch their fields without clearing the rest.
+func createUpdateEnvironmentFromEnvironment(env *github.Environment) github.CreateUpdateEnvironment {
+	data := github.CreateUpdateEnvironment{
+		CanAdminsBypass:        env.CanAdminsBypass,
+		DeploymentBranchPolicy: env.DeploymentBranchPolicy,
+	}
+
+	for _, pr := range env.ProtectionRules {
+		switch pr.GetType() {
+		case "wait_timer":
+			data.WaitTimer = pr.WaitTimer
+
+		case "required_reviewers":
+			data.PreventSelfReview = pr.PreventSelfReview
+			teams, users := flattenEnvironmentReviewerIDs(pr.Reviewers, false)
+			data.Reviewers = buildEnvironmentReviewers(teams, users)
 		}
 	}
 
 	return data
 }
 
+// buildEnvironmentReviewers converts team and user IDs into the reviewers
+// payload accepted by CreateUpdateEnvironment. Duplicate IDs are removed and
+// the teams and users are each sorted by ID, so that the request body does not
+// depend on the order in which the sets were iterated.
+func buildEnvironmentReviewers(teams, users []int64) []*github.EnvReviewers {
+	envReviewers := make([]*github.EnvReviewers, 0)
+
+	teams = slices.Compact(slices.Sorted(slices.Values(teams)))
+	users = slices.Compact(slices.Sorted(slices.Values(users)))
+
+	for _, team := range teams {
+		envReviewers = append(envReviewers, &github.EnvReviewers{
+			Type: github.Ptr("Team"),
+			ID:   github.Ptr(team),
+		})
+	}
+
+	for _, user := range users {
+		envReviewers = append(envReviewers, &github.EnvReviewers{
+			Type: github.Ptr("User"),
+			ID:   github.Ptr(user),
+		})
+	}
+
+	return envReviewers
+}
+
 func expandReviewers(v any, target string) []int64 {
 	res := make([]int64, 0)
-	m := v.([]any)[0]
-	if m != nil {
-		if v, ok := m.(map[string]any)[target]; ok {
-			vL := v.(*schema.Set).List()
-			for _, v := range vL {
+	// A present but empty reviewers list, e.g. from a dynamic block, has no
+	// element at all, and an empty block has a nil one. Both mean no reviewers.
+	l, ok := v.([]any)
+	if !ok || len(l) == 0 {
+		return res
+	}
+	if m, ok := l[0].(map[string]any); ok {
+		if v, ok := m[target].(*schema.Set); ok {
+			for _, v := range v.List() {
 				res = append(res, int64(v.(int)))
 			}
 		}
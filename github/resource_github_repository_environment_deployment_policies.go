@@ -0,0 +1,260 @@
+package github
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/google/go-github/v82/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceGithubRepositoryEnvironmentDeploymentPolicies() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubRepositoryEnvironmentDeploymentPoliciesCreateOrUpdate,
+		ReadContext:   resourceGithubRepositoryEnvironmentDeploymentPoliciesRead,
+		UpdateContext: resourceGithubRepositoryEnvironmentDeploymentPoliciesCreateOrUpdate,
+		DeleteContext: resourceGithubRepositoryEnvironmentDeploymentPoliciesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGithubRepositoryEnvironmentImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the GitHub repository.",
+			},
+			"environment": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the environment.",
+			},
+			"branch_patterns": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString, ValidateDiagFunc: toDiagFunc(validation.StringIsNotEmpty, "branch_patterns")},
+				AtLeastOneOf: []string{"branch_patterns", "tag_patterns"},
+				Description:  "The name patterns that branches must match in order to deploy to the environment.",
+			},
+			"tag_patterns": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString, ValidateDiagFunc: toDiagFunc(validation.StringIsNotEmpty, "tag_patterns")},
+				AtLeastOneOf: []string{"branch_patterns", "tag_patterns"},
+				Description:  "The name patterns that tags must match in order to deploy to the environment.",
+			},
+			"policy": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The deployment policies of the environment as created in GitHub.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the deployment policy.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the deployment policy, either 'branch' or 'tag'.",
+						},
+						"pattern": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name pattern of the deployment policy.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceGithubRepositoryEnvironmentDeploymentPoliciesCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+
+	repoName := d.Get("repository").(string)
+	envName := d.Get("environment").(string)
+
+	existing, err := listEnvironmentDeploymentPolicies(ctx, client, owner, repoName, envName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	desired := make(map[deploymentPolicyKey]bool)
+	for _, v := range d.Get("branch_patterns").(*schema.Set).List() {
+		desired[deploymentPolicyKey{policyType: "branch", pattern: v.(string)}] = true
+	}
+	for _, v := range d.Get("tag_patterns").(*schema.Set).List() {
+		desired[deploymentPolicyKey{policyType: "tag", pattern: v.(string)}] = true
+	}
+
+	for _, policy := range existing {
+		key := deploymentPolicyKey{policyType: policy.GetType(), pattern: policy.GetName()}
+		if desired[key] {
+			delete(desired, key)
+			continue
+		}
+
+		log.Printf("[DEBUG] Deleting %s deployment policy %q of environment %s", key.policyType, key.pattern, envName)
+		if _, err := client.Repositories.DeleteDeploymentBranchPolicy(ctx, owner, repoName, url.PathEscape(envName), policy.GetID()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	missing := make([]deploymentPolicyKey, 0, len(desired))
+	for key := range desired {
+		missing = append(missing, key)
+	}
+	slices.SortFunc(missing, func(a, b deploymentPolicyKey) int {
+		return cmp.Or(cmp.Compare(a.policyType, b.policyType), cmp.Compare(a.pattern, b.pattern))
+	})
+
+	for _, key := range missing {
+		log.Printf("[DEBUG] Creating %s deployment policy %q of environment %s", key.policyType, key.pattern, envName)
+		_, _, err := client.Repositories.CreateDeploymentBranchPolicy(ctx, owner, repoName, url.PathEscape(envName), &github.DeploymentBranchPolicyRequest{
+			Name: github.Ptr(key.pattern),
+			Type: github.Ptr(key.policyType),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	id, err := buildID(repoName, escapeIDPart(envName))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	return resourceGithubRepositoryEnvironmentDeploymentPoliciesRead(ctx, d, meta)
+}
+
+func resourceGithubRepositoryEnvironmentDeploymentPoliciesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+
+	repoName, envNamePart, err := parseID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	envName := unescapeIDPartFromState(d, "environment", envNamePart)
+
+	policies, err := listEnvironmentDeploymentPolicies(ctx, client, owner, repoName, envName)
+	if err != nil {
+		var ghErr *github.ErrorResponse
+		if errors.As(err, &ghErr) {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing deployment policies of environment %s from state because it no longer exists in GitHub",
+					d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return diag.FromErr(err)
+	}
+
+	branchPatterns := make([]string, 0)
+	tagPatterns := make([]string, 0)
+	flattened := make([]any, 0, len(policies))
+	for _, policy := range policies {
+		switch policy.GetType() {
+		case "tag":
+			tagPatterns = append(tagPatterns, policy.GetName())
+		default:
+			branchPatterns = append(branchPatterns, policy.GetName())
+		}
+
+		flattened = append(flattened, map[string]any{
+			"id":      policy.GetID(),
+			"type":    policy.GetType(),
+			"pattern": policy.GetName(),
+		})
+	}
+
+	_ = d.Set("repository", repoName)
+	_ = d.Set("environment", envName)
+	if err := d.Set("branch_patterns", branchPatterns); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tag_patterns", tagPatterns); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("policy", flattened); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubRepositoryEnvironmentDeploymentPoliciesDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+
+	repoName, envNamePart, err := parseID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	envName := unescapeIDPartFromState(d, "environment", envNamePart)
+
+	for _, v := range d.Get("policy").([]any) {
+		policy := v.(map[string]any)
+		_, err := client.Repositories.DeleteDeploymentBranchPolicy(ctx, owner, repoName, url.PathEscape(envName), int64(policy["id"].(int)))
+		if err != nil {
+			var ghErr *github.ErrorResponse
+			if errors.As(err, &ghErr) && ghErr.Response.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// deploymentPolicyKey identifies a deployment policy by what it matches, since
+// GitHub does not allow two policies of the same type with the same pattern.
+type deploymentPolicyKey struct {
+	policyType string
+	pattern    string
+}
+
+// listEnvironmentDeploymentPolicies reads all deployment policies of an
+// environment. go-github does not expose list options for the endpoint, so the
+// pages are requested directly.
+func listEnvironmentDeploymentPolicies(ctx context.Context, client *github.Client, owner, repoName, envName string) ([]*github.DeploymentBranchPolicy, error) {
+	policies := make([]*github.DeploymentBranchPolicy, 0)
+	page := 1
+	for {
+		u := fmt.Sprintf("repos/%v/%v/environments/%v/deployment-branch-policies?per_page=%d&page=%d", owner, repoName, url.PathEscape(envName), maxPerPage, page)
+		req, err := client.NewRequest("GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list github.DeploymentBranchPolicyResponse
+		resp, err := client.Do(ctx, req, &list)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, list.BranchPolicies...)
+
+		if resp.NextPage == 0 {
+			return policies, nil
+		}
+		page = resp.NextPage
+	}
+}
@@ -0,0 +1,173 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccGithubRepositoryEnvironmentDeploymentPoliciesResource(t *testing.T) {
+	t.Run("adds and removes patterns", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		repoName := fmt.Sprintf("%srepo-env-policies-%s", testResourcePrefix, randomID)
+		config := `
+			resource "github_repository" "test" {
+				name       = "%s"
+				visibility = "public"
+			}
+
+			resource "github_repository_environment" "test" {
+				repository  = github_repository.test.name
+				environment = "production"
+
+				deployment_branch_policy {
+					custom_branch_policies = true
+				}
+			}
+
+			resource "github_repository_environment_deployment_policies" "test" {
+				repository      = github_repository.test.name
+				environment     = github_repository_environment.test.environment
+				branch_patterns = %s
+				tag_patterns    = %s
+			}
+		`
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnauthenticated(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(config, repoName, `["main"]`, `[]`),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("github_repository_environment_deployment_policies.test", "branch_patterns.#", "1"),
+						resource.TestCheckResourceAttr("github_repository_environment_deployment_policies.test", "tag_patterns.#", "0"),
+						resource.TestCheckResourceAttr("github_repository_environment_deployment_policies.test", "policy.#", "1"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(config, repoName, `["main", "releases/*"]`, `["v*"]`),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("github_repository_environment_deployment_policies.test", "branch_patterns.#", "2"),
+						resource.TestCheckResourceAttr("github_repository_environment_deployment_policies.test", "tag_patterns.#", "1"),
+						resource.TestCheckResourceAttr("github_repository_environment_deployment_policies.test", "policy.#", "3"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(config, repoName, `["releases/*"]`, `[]`),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckTypeSetElemAttr("github_repository_environment_deployment_policies.test", "branch_patterns.*", "releases/*"),
+						resource.TestCheckResourceAttr("github_repository_environment_deployment_policies.test", "branch_patterns.#", "1"),
+						resource.TestCheckResourceAttr("github_repository_environment_deployment_policies.test", "tag_patterns.#", "0"),
+						resource.TestCheckResourceAttr("github_repository_environment_deployment_policies.test", "policy.#", "1"),
+					),
+				},
+			},
+		})
+	})
+}
+
+func TestGithubRepositoryEnvironmentDeploymentPoliciesReconcile(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri: "/repos/test-owner/test-repo/environments/production/deployment-branch-policies?per_page=100&page=1",
+			ResponseBody: `{"total_count": 2, "branch_policies": [
+				{"id": 1, "name": "main", "type": "branch"},
+				{"id": 2, "name": "old/*", "type": "branch"}
+			]}`,
+			StatusCode: 200,
+		},
+		{
+			ExpectedUri:    "/repos/test-owner/test-repo/environments/production/deployment-branch-policies/2",
+			ExpectedMethod: "DELETE",
+			StatusCode:     204,
+		},
+		{
+			ExpectedUri:    "/repos/test-owner/test-repo/environments/production/deployment-branch-policies",
+			ExpectedMethod: "POST",
+			ExpectedBody:   []byte(`{"name":"releases/*","type":"branch"}` + "\n"),
+			ResponseBody:   `{"id": 3, "name": "releases/*", "type": "branch"}`,
+			StatusCode:     200,
+		},
+		{
+			ExpectedUri:    "/repos/test-owner/test-repo/environments/production/deployment-branch-policies",
+			ExpectedMethod: "POST",
+			ExpectedBody:   []byte(`{"name":"v*","type":"tag"}` + "\n"),
+			ResponseBody:   `{"id": 4, "name": "v*", "type": "tag"}`,
+			StatusCode:     200,
+		},
+		{
+			ExpectedUri: "/repos/test-owner/test-repo/environments/production/deployment-branch-policies?per_page=100&page=1",
+			ResponseBody: `{"total_count": 3, "branch_policies": [
+				{"id": 1, "name": "main", "type": "branch"},
+				{"id": 3, "name": "releases/*", "type": "branch"},
+				{"id": 4, "name": "v*", "type": "tag"}
+			]}`,
+			StatusCode: 200,
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceGithubRepositoryEnvironmentDeploymentPolicies().Schema, map[string]any{
+		"repository":      "test-repo",
+		"environment":     "production",
+		"branch_patterns": []any{"main", "releases/*"},
+		"tag_patterns":    []any{"v*"},
+	})
+
+	if diags := resourceGithubRepositoryEnvironmentDeploymentPoliciesCreateOrUpdate(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Id(); got != "test-repo:production" {
+		t.Errorf("expected id test-repo:production, got %s", got)
+	}
+
+	policies := d.Get("policy").([]any)
+	if len(policies) != 3 {
+		t.Fatalf("expected 3 policies, got %d", len(policies))
+	}
+	for i, expectedID := range []int{1, 3, 4} {
+		if got := policies[i].(map[string]any)["id"]; got != expectedID {
+			t.Errorf("expected policy %d to have id %d, got %v", i, expectedID, got)
+		}
+	}
+}
+
+func TestGithubRepositoryEnvironmentDeploymentPoliciesReadPaginates(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri: "/repos/test-owner/test-repo/environments/production/deployment-branch-policies?per_page=100&page=1",
+			ResponseHeaders: map[string]string{
+				"Link": `<https://api.github.com/repos/test-owner/test-repo/environments/production/deployment-branch-policies?per_page=100&page=2>; rel="next"`,
+			},
+			ResponseBody: `{"total_count": 3, "branch_policies": [
+				{"id": 1, "name": "main", "type": "branch"},
+				{"id": 2, "name": "releases/*", "type": "branch"}
+			]}`,
+			StatusCode: 200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/environments/production/deployment-branch-policies?per_page=100&page=2",
+			ResponseBody: `{"total_count": 3, "branch_policies": [{"id": 3, "name": "v*", "type": "tag"}]}`,
+			StatusCode:   200,
+		},
+	})
+
+	d := resourceGithubRepositoryEnvironmentDeploymentPolicies().TestResourceData()
+	d.SetId("test-repo:production")
+
+	if diags := resourceGithubRepositoryEnvironmentDeploymentPoliciesRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := len(d.Get("policy").([]any)); got != 3 {
+		t.Fatalf("expected the policies of both pages, got %d", got)
+	}
+	if got := d.Get("tag_patterns").(*schema.Set); got.Len() != 1 || !got.Contains("v*") {
+		t.Errorf("expected tag pattern v* from the second page, got %v", got.List())
+	}
+}
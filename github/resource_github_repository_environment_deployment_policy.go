@@ -131,7 +131,7 @@ func resourceGithubRepositoryEnvironmentDeploymentPolicyRead(ctx context.Context
 		return diag.FromErr(err)
 	}
 
-	envName := unescapeIDPart(envNamePart)
+	envName := unescapeIDPartFromState(d, "environment", envNamePart)
 
 	branchPolicyId, err := strconv.ParseInt(branchPolicyIdString, 10, 64)
 	if err != nil {
@@ -213,7 +213,7 @@ func resourceGithubRepositoryEnvironmentDeploymentPolicyDelete(ctx context.Conte
 		return diag.FromErr(err)
 	}
 
-	envName := unescapeIDPart(envNamePart)
+	envName := unescapeIDPartFromState(d, "environment", envNamePart)
 
 	branchPolicyId, err := strconv.ParseInt(branchPolicyIdString, 10, 64)
 	if err != nil {
@@ -625,6 +625,72 @@ func TestAccGithubRepositoryEnvironmentDeploymentPolicy(t *testing.T) {
 		})
 	})
 
+	t.Run("creates branch and tag deployment policies on the same environment", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		repoName := fmt.Sprintf("%srepo-env-deploy-%s", testResourcePrefix, randomID)
+		config := fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name = "%s"
+			}
+
+			resource "github_repository_environment" "test" {
+				repository  = github_repository.test.name
+				environment = "environment / test"
+				deployment_branch_policy {
+					protected_branches     = false
+					custom_branch_policies = true
+				}
+			}
+
+			resource "github_repository_environment_deployment_policy" "branch" {
+				repository     = github_repository.test.name
+				environment    = github_repository_environment.test.environment
+				branch_pattern = "releases/*"
+			}
+
+			resource "github_repository_environment_deployment_policy" "tag" {
+				repository  = github_repository.test.name
+				environment = github_repository_environment.test.environment
+				tag_pattern = "v*"
+			}
+
+			data "github_repository_environment_deployment_policies" "test" {
+				repository  = github_repository.test.name
+				environment = github_repository_environment.test.environment
+				depends_on  = [
+					github_repository_environment_deployment_policy.branch,
+					github_repository_environment_deployment_policy.tag,
+				]
+			}
+		`, repoName)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr("github_repository_environment_deployment_policy.branch", "branch_pattern", "releases/*"),
+			resource.TestCheckResourceAttr("github_repository_environment_deployment_policy.tag", "tag_pattern", "v*"),
+			resource.TestCheckResourceAttr("github_repository_environment.test", "deployment_branch_policy.0.custom_branch_policies", "true"),
+			resource.TestCheckResourceAttr("data.github_repository_environment_deployment_policies.test", "policies.#", "2"),
+			resource.TestCheckTypeSetElemNestedAttrs("data.github_repository_environment_deployment_policies.test", "policies.*", map[string]string{
+				"type":    "branch",
+				"pattern": "releases/*",
+			}),
+			resource.TestCheckTypeSetElemNestedAttrs("data.github_repository_environment_deployment_policies.test", "policies.*", map[string]string{
+				"type":    "tag",
+				"pattern": "v*",
+			}),
+		)
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnauthenticated(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check:  check,
+				},
+			},
+		})
+	})
+
 	t.Run("import", func(t *testing.T) {
 		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
 		repoName := fmt.Sprintf("%s%s", testResourcePrefix, randomID)
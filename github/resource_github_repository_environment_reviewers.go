@@ -0,0 +1,160 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/google/go-github/v82/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubRepositoryEnvironmentReviewers() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubRepositoryEnvironmentReviewersCreateOrUpdate,
+		ReadContext:   resourceGithubRepositoryEnvironmentReviewersRead,
+		UpdateContext: resourceGithubRepositoryEnvironmentReviewersCreateOrUpdate,
+		DeleteContext: resourceGithubRepositoryEnvironmentReviewersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGithubRepositoryEnvironmentImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The repository of the environment.",
+			},
+			"environment": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the environment.",
+			},
+			"teams": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeInt},
+				AtLeastOneOf: []string{"teams", "users"},
+				Description:  "Up to 6 IDs for teams who may review jobs that reference the environment.",
+			},
+			"users": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeInt},
+				AtLeastOneOf: []string{"teams", "users"},
+				Description:  "Up to 6 IDs for users who may review jobs that reference the environment.",
+			},
+		},
+	}
+}
+
+func resourceGithubRepositoryEnvironmentReviewersCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	repoName := d.Get("repository").(string)
+	envName := d.Get("environment").(string)
+
+	teams := expandEnvironmentReviewerIDs(d.Get("teams").(*schema.Set))
+	users := expandEnvironmentReviewerIDs(d.Get("users").(*schema.Set))
+
+	if err := updateEnvironmentReviewers(ctx, meta.(*Owner), repoName, envName, buildEnvironmentReviewers(teams, users)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, err := buildID(repoName, escapeIDPart(envName))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+
+	return resourceGithubRepositoryEnvironmentReviewersRead(ctx, d, meta)
+}
+
+func resourceGithubRepositoryEnvironmentReviewersRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+
+	repoName, envNamePart, err := parseID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	envName := unescapeIDPartFromState(d, "environment", envNamePart)
+
+	env, _, err := client.Repositories.GetEnvironment(ctx, owner, repoName, url.PathEscape(envName))
+	if err != nil {
+		var ghErr *github.ErrorResponse
+		if errors.As(err, &ghErr) {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing repository environment reviewers %s from state because the environment no longer exists in GitHub",
+					d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return diag.FromErr(err)
+	}
+
+	teams := make([]int64, 0)
+	users := make([]int64, 0)
+	for _, pr := range env.ProtectionRules {
+		if pr.GetType() == "required_reviewers" {
+			teams, users = flattenEnvironmentReviewerIDs(pr.Reviewers, false)
+		}
+	}
+
+	_ = d.Set("repository", repoName)
+	_ = d.Set("environment", envName)
+	if err = d.Set("teams", teams); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("users", users); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubRepositoryEnvironmentReviewersDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	repoName, envNamePart, err := parseID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	envName := unescapeIDPartFromState(d, "environment", envNamePart)
+
+	err = updateEnvironmentReviewers(ctx, meta.(*Owner), repoName, envName, nil)
+	if err != nil {
+		return diag.FromErr(deleteResourceOn404AndSwallow304OtherwiseReturnError(err, d, "environment reviewers (%s)", envName))
+	}
+
+	return nil
+}
+
+// updateEnvironmentReviewers replaces the reviewers of an existing environment
+// while re-applying all of its other settings unchanged.
+func updateEnvironmentReviewers(ctx context.Context, meta *Owner, repoName, envName string, reviewers []*github.EnvReviewers) error {
+	client := meta.v3client
+	owner := meta.name
+
+	env, _, err := client.Repositories.GetEnvironment(ctx, owner, repoName, url.PathEscape(envName))
+	if err != nil {
+		return err
+	}
+
+	updateData := createUpdateEnvironmentFromEnvironment(env)
+	updateData.Reviewers = reviewers
+
+	_, _, err = client.Repositories.CreateUpdateEnvironment(ctx, owner, repoName, url.PathEscape(envName), &updateData)
+	return err
+}
+
+func expandEnvironmentReviewerIDs(s *schema.Set) []int64 {
+	ids := make([]int64, 0, s.Len())
+	for _, v := range s.List() {
+		ids = append(ids, int64(v.(int)))
+	}
+	return ids
+}
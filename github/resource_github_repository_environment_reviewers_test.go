@@ -0,0 +1,191 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubRepositoryEnvironmentReviewers(t *testing.T) {
+	t.Run("manages reviewers independently of the environment", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		repoName := fmt.Sprintf("%srepo-env-reviewers-%s", testResourcePrefix, randomID)
+		teamName := fmt.Sprintf("%steam-env-reviewers-%s", testResourcePrefix, randomID)
+
+		base := fmt.Sprintf(`
+			data "github_user" "current" {
+				username = ""
+			}
+
+			resource "github_repository" "test" {
+				name       = "%s"
+				visibility = "public"
+			}
+
+			resource "github_team" "test" {
+				name = "%s"
+			}
+
+			resource "github_team_repository" "test" {
+				team_id    = github_team.test.id
+				repository = github_repository.test.name
+				permission = "push"
+			}
+
+			resource "github_repository_environment" "test" {
+				repository  = github_repository.test.name
+				environment = "production"
+				wait_timer  = 10
+
+				lifecycle {
+					ignore_changes = [reviewers, prevent_self_review]
+				}
+			}
+		`, repoName, teamName)
+
+		createConfig := base + `
+			resource "github_repository_environment_reviewers" "test" {
+				repository  = github_repository.test.name
+				environment = github_repository_environment.test.environment
+				users       = [data.github_user.current.id]
+			}
+		`
+
+		updateConfig := base + `
+			resource "github_repository_environment_reviewers" "test" {
+				repository  = github_repository.test.name
+				environment = github_repository_environment.test.environment
+				teams       = [github_team.test.id]
+				depends_on  = [github_team_repository.test]
+			}
+		`
+
+		deleteConfig := base + `
+			data "github_repository_environment" "test" {
+				repository  = github_repository.test.name
+				environment = github_repository_environment.test.environment
+			}
+		`
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnlessHasOrgs(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: createConfig,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("github_repository_environment_reviewers.test", "users.#", "1"),
+						resource.TestCheckResourceAttr("github_repository_environment_reviewers.test", "teams.#", "0"),
+					),
+				},
+				{
+					Config: updateConfig,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("github_repository_environment_reviewers.test", "users.#", "0"),
+						resource.TestCheckResourceAttr("github_repository_environment_reviewers.test", "teams.#", "1"),
+					),
+				},
+				{
+					Config: deleteConfig,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("data.github_repository_environment.test", "reviewers.#", "0"),
+						resource.TestCheckResourceAttr("data.github_repository_environment.test", "wait_timer", "10"),
+					),
+				},
+			},
+		})
+	})
+}
+
+func TestAccGithubRepositoryEnvironmentReviewersCoexistence(t *testing.T) {
+	t.Run("environment updates keep reviewers owned by the sub-resource", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		repoName := fmt.Sprintf("%srepo-env-reviewers-%s", testResourcePrefix, randomID)
+
+		config := `
+			data "github_user" "current" {
+				username = ""
+			}
+
+			resource "github_repository" "test" {
+				name       = "%s"
+				visibility = "public"
+			}
+
+			resource "github_repository_environment" "test" {
+				repository  = github_repository.test.name
+				environment = "production"
+				wait_timer  = %d
+
+				lifecycle {
+					ignore_changes = [reviewers, prevent_self_review]
+				}
+			}
+
+			resource "github_repository_environment_reviewers" "test" {
+				repository  = github_repository.test.name
+				environment = github_repository_environment.test.environment
+				users       = [data.github_user.current.id]
+			}
+
+			data "github_repository_environment" "test" {
+				repository  = github_repository.test.name
+				environment = github_repository_environment.test.environment
+				depends_on  = [github_repository_environment_reviewers.test]
+			}
+		`
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnauthenticated(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(config, repoName, 10),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("data.github_repository_environment.test", "reviewers.0.users.#", "1"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(config, repoName, 20),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("data.github_repository_environment.test", "wait_timer", "20"),
+						resource.TestCheckResourceAttr("data.github_repository_environment.test", "reviewers.0.users.#", "1"),
+					),
+				},
+			},
+		})
+	})
+}
+
+func TestUpdateEnvironmentReviewersPreservesOtherSettings(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri: "/repos/test-owner/test-repo/environments/production",
+			ResponseBody: `{
+				"name": "production",
+				"can_admins_bypass": false,
+				"deployment_branch_policy": {"protected_branches": true, "custom_branch_policies": false},
+				"protection_rules": [
+					{"type": "wait_timer", "wait_timer": 30},
+					{"type": "required_reviewers", "prevent_self_review": true, "reviewers": [{"type": "User", "reviewer": {"id": 1}}]}
+				]
+			}`,
+			StatusCode: 200,
+		},
+		{
+			ExpectedUri:    "/repos/test-owner/test-repo/environments/production",
+			ExpectedMethod: "PUT",
+			ExpectedBody:   []byte(`{"wait_timer":30,"reviewers":[{"type":"Team","id":2}],"can_admins_bypass":false,"deployment_branch_policy":{"protected_branches":true,"custom_branch_policies":false},"prevent_self_review":true}` + "\n"),
+			ResponseBody:   `{"name": "production"}`,
+			StatusCode:     200,
+		},
+	})
+
+	err := updateEnvironmentReviewers(context.Background(), meta, "test-repo", "production", buildEnvironmentReviewers([]int64{2}, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
@@ -1,11 +1,21 @@
 package github
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/google/go-github/v82/github"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccGithubRepositoryEnvironment(t *testing.T) {
@@ -113,6 +123,120 @@ func TestAccGithubRepositoryEnvironment(t *testing.T) {
 		})
 	})
 
+	t.Run("round-trips wait_timer in minutes", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		repoName := fmt.Sprintf("%s%s", testResourcePrefix, randomID)
+		config := fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name       = "%s"
+				visibility = "public"
+			}
+
+			resource "github_repository_environment" "test" {
+				repository  = github_repository.test.name
+				environment = "test"
+				wait_timer  = 60
+			}
+		`, repoName)
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnauthenticated(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("github_repository_environment.test", "wait_timer", "60"),
+					),
+				},
+				{
+					// A follow-up plan must be empty, so the value read back
+					// uses the same unit as the value written.
+					Config:   config,
+					PlanOnly: true,
+				},
+			},
+		})
+	})
+
+	t.Run("switches from custom branch policies to all branches", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		repoName := fmt.Sprintf("%s%s", testResourcePrefix, randomID)
+		config := `
+			resource "github_repository" "test" {
+				name       = "%s"
+				visibility = "public"
+			}
+
+			resource "github_repository_environment" "test" {
+				repository  = github_repository.test.name
+				environment = "test"
+
+				deployment_branch_policy {
+					%s
+				}
+			}
+		`
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnauthenticated(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(config, repoName, "custom_branch_policies = true"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("github_repository_environment.test", "deployment_branch_policy.0.custom_branch_policies", "true"),
+					),
+				},
+				{
+					Config: fmt.Sprintf(config, repoName, "all_branches = true"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("github_repository_environment.test", "deployment_branch_policy.0.all_branches", "true"),
+						resource.TestCheckResourceAttr("github_repository_environment.test", "deployment_branch_policy.0.custom_branch_policies", "false"),
+					),
+				},
+				{
+					Config:      fmt.Sprintf(config, repoName, "all_branches = true\n\t\t\t\t\tprotected_branches = true"),
+					ExpectError: regexp.MustCompile("all_branches cannot be combined"),
+				},
+			},
+		})
+	})
+
+	t.Run("creates a repository environment on a fork", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		repoName := fmt.Sprintf("%sfork-env-%s", testResourcePrefix, randomID)
+		config := fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name         = "%s"
+				fork         = true
+				source_owner = "integrations"
+				source_repo  = "terraform-provider-github"
+			}
+
+			resource "github_repository_environment" "test" {
+				repository  = github_repository.test.name
+				environment = "production"
+				wait_timer  = 10
+			}
+		`, repoName)
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnauthenticated(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr("github_repository.test", "fork", "true"),
+						resource.TestCheckResourceAttr("github_repository_environment.test", "environment", "production"),
+						resource.TestCheckResourceAttr("github_repository_environment.test", "wait_timer", "10"),
+					),
+				},
+			},
+		})
+	})
+
 	t.Run("import", func(t *testing.T) {
 		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
 		repoName := fmt.Sprintf("%s%s", testResourcePrefix, randomID)
@@ -146,3 +270,1296 @@ func TestAccGithubRepositoryEnvironment(t *testing.T) {
 		})
 	})
 }
+
+func TestValidateEnvironmentReviewerTeams(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:  "/organizations/1234/team/1",
+			ResponseBody: `{"id": 1, "slug": "ours"}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:  "/organizations/1234/team/999",
+			ResponseBody: `{"message": "Not Found"}`,
+			StatusCode:   404,
+		},
+	})
+	meta.name = "test-org"
+	meta.id = 1234
+	meta.IsOrganization = true
+
+	err := validateEnvironmentReviewerTeams(context.Background(), meta, []int64{1, 999})
+	if err == nil {
+		t.Fatal("expected an error for a team from another organization")
+	}
+
+	if !strings.Contains(err.Error(), "reviewer team 999 does not belong to organization test-org") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestGithubRepositoryEnvironmentArchivedRepository(t *testing.T) {
+	archivedRepo := &mockResponse{
+		ExpectedUri:  "/repos/test-owner/test-repo",
+		ResponseBody: `{"name": "test-repo", "archived": true}`,
+		StatusCode:   200,
+	}
+	meta := newMockOwner(t, []*mockResponse{
+		archivedRepo,
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/environments/production",
+			ResponseBody: `{"id": 42, "name": "production"}`,
+			StatusCode:   200,
+		},
+		archivedRepo,
+		archivedRepo,
+		{
+			ExpectedUri:    "/repos/test-owner/test-repo/environments/production",
+			ExpectedMethod: "DELETE",
+			StatusCode:     204,
+		},
+	})
+
+	d := resourceGithubRepositoryEnvironment().TestResourceData()
+	d.SetId("test-repo:production")
+	_ = d.Set("repository", "test-repo")
+	_ = d.Set("environment", "production")
+
+	// A refresh keeps the environment in state, so that destroy still reaches
+	// the API.
+	if diags := resourceGithubRepositoryEnvironmentRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error on read: %v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatal("expected the environment to be kept in state on refresh")
+	}
+
+	diags := resourceGithubRepositoryEnvironmentUpdate(context.Background(), d, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "repository test-repo is archived") {
+		t.Fatalf("expected update to fail on the archived repository, got %v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatal("expected a failed update to keep the environment in state")
+	}
+
+	if diags := resourceGithubRepositoryEnvironmentDelete(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error on delete: %v", diags)
+	}
+
+	// The environment must have been deleted through the API rather than only
+	// removed from state.
+	if d.Id() == "" {
+		t.Fatal("expected the environment to be deleted, not dropped from state")
+	}
+}
+
+func TestGithubRepositoryEnvironmentDeleteInvalidatesPublicKey(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			// A failed repository lookup must not keep the key cached.
+			ExpectedUri:  "/repos/test-owner/test-repo",
+			ResponseBody: `{"message": "Server Error"}`,
+			StatusCode:   500,
+		},
+		{
+			ExpectedUri:    "/repos/test-owner/test-repo/environments/production",
+			ExpectedMethod: "DELETE",
+			StatusCode:     204,
+		},
+	})
+	meta.environmentPublicKeys.set("test-owner/test-repo/production", "key-1", "cHVibGljLWtleQ==")
+
+	d := resourceGithubRepositoryEnvironment().TestResourceData()
+	d.SetId("test-repo:production")
+	_ = d.Set("repository", "test-repo")
+	_ = d.Set("environment", "production")
+
+	if diags := resourceGithubRepositoryEnvironmentDelete(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error on delete: %v", diags)
+	}
+
+	if _, _, ok := meta.environmentPublicKeys.get("test-owner/test-repo/production"); ok {
+		t.Error("expected the public key of the deleted environment to be dropped from the cache")
+	}
+}
+
+func TestGithubRepositoryEnvironmentCreateWarnsOnUnprotectedRepository(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:    "/repos/test-owner/test-repo/environments/production",
+			ExpectedMethod: "PUT",
+			ResponseBody:   `{"name": "production"}`,
+			StatusCode:     200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/branches?per_page=1&protected=true",
+			ResponseBody: `[]`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo",
+			ResponseBody: `{"name": "test-repo", "html_url": "https://github.com/test-owner/test-repo"}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/environments/production",
+			ResponseBody: `{"id": 42, "name": "production", "deployment_branch_policy": {"protected_branches": true, "custom_branch_policies": false}}`,
+			StatusCode:   200,
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceGithubRepositoryEnvironment().Schema, map[string]any{
+		"repository":  "test-repo",
+		"environment": "production",
+		"deployment_branch_policy": []any{
+			map[string]any{
+				"protected_branches":     true,
+				"custom_branch_policies": false,
+			},
+		},
+	})
+
+	diags := resourceGithubRepositoryEnvironmentCreate(context.Background(), d, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if !strings.Contains(diags[0].Detail, "test-repo has no protected branches") {
+		t.Errorf("unexpected warning detail: %s", diags[0].Detail)
+	}
+
+	// The result is cached, so a second environment on the same repository
+	// must not list branches again.
+	if _, ok := meta.protectedBranches.get("test-owner/test-repo"); !ok {
+		t.Error("expected the protected branch lookup to be cached")
+	}
+
+	if got := d.Get("environment_url"); got != "https://github.com/test-owner/test-repo/settings/environments/42" {
+		t.Errorf("unexpected environment_url: %v", got)
+	}
+}
+
+func TestGithubRepositoryEnvironmentDiffUserRepository(t *testing.T) {
+	// Any request falls off the end of the empty mock sequence and fails the
+	// diff, so team reviewers must not be looked up for a personal account.
+	meta := newMockOwner(t, []*mockResponse{})
+	meta.name = "test-user"
+	meta.IsOrganization = false
+	meta.validateEnvironmentReviewers = true
+
+	config := terraform.NewResourceConfigRaw(map[string]any{
+		"repository":  "test-repo",
+		"environment": "production",
+		"reviewers": []any{
+			map[string]any{
+				"teams": []any{1, 2},
+			},
+		},
+	})
+
+	diff, err := resourceGithubRepositoryEnvironment().Diff(context.Background(), nil, config, meta)
+	if err != nil {
+		t.Fatalf("unexpected error validating team reviewers on a personal account: %s", err)
+	}
+	if diff == nil || diff.Attributes["reviewers.#"] == nil {
+		t.Fatal("expected a diff adding the reviewers")
+	}
+}
+
+func TestGithubRepositoryEnvironmentSpecialCharacters(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:    "/repos/test-owner/test-repo/environments/eu.prod:blue",
+			ExpectedMethod: "PUT",
+			ResponseBody:   `{"name": "eu.prod:blue"}`,
+			StatusCode:     200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo",
+			ResponseBody: `{"name": "test-repo"}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/environments/eu.prod:blue",
+			ResponseBody: `{"id": 42, "name": "eu.prod:blue"}`,
+			StatusCode:   200,
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceGithubRepositoryEnvironment().Schema, map[string]any{
+		"repository":  "test-repo",
+		"environment": "eu.prod:blue",
+	})
+
+	if diags := resourceGithubRepositoryEnvironmentCreate(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// The colon is escaped in the ID only, the environment keeps the name
+	// GitHub returned for the same path.
+	if got := d.Id(); got != "test-repo:eu.prod??blue" {
+		t.Errorf("expected id test-repo:eu.prod??blue, got %s", got)
+	}
+	if got := d.Get("environment"); got != "eu.prod:blue" {
+		t.Errorf("expected environment eu.prod:blue, got %v", got)
+	}
+}
+
+func TestGithubRepositoryEnvironmentCreateWarnsOnDisabledActions(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:    "/repos/test-owner/test-repo/environments/production",
+			ExpectedMethod: "PUT",
+			ResponseBody:   `{"name": "production"}`,
+			StatusCode:     200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/actions/permissions",
+			ResponseBody: `{"enabled": false}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo",
+			ResponseBody: `{"name": "test-repo", "html_url": "https://github.com/test-owner/test-repo"}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/environments/production",
+			ResponseBody: `{"id": 42, "name": "production"}`,
+			StatusCode:   200,
+		},
+	})
+	meta.warnOnDisabledActions = true
+
+	d := schema.TestResourceDataRaw(t, resourceGithubRepositoryEnvironment().Schema, map[string]any{
+		"repository":  "test-repo",
+		"environment": "production",
+	})
+
+	diags := resourceGithubRepositoryEnvironmentCreate(context.Background(), d, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if !strings.Contains(diags[0].Detail, "GitHub Actions is disabled on repository test-repo") {
+		t.Errorf("unexpected warning detail: %s", diags[0].Detail)
+	}
+}
+
+func TestGithubRepositoryEnvironmentCreateDroppedReviewers(t *testing.T) {
+	putResponse := &mockResponse{
+		ExpectedUri:    "/repos/test-owner/test-repo/environments/production",
+		ExpectedMethod: "PUT",
+		ResponseBody: `{"name": "production", "protection_rules": [
+			{"type": "required_reviewers", "reviewers": [{"type": "User", "reviewer": {"id": 1}}]}
+		]}`,
+		StatusCode: 200,
+	}
+
+	for _, tc := range []struct {
+		name      string
+		strict    bool
+		responses []*mockResponse
+		severity  diag.Severity
+	}{
+		{
+			name:   "strict",
+			strict: true,
+			responses: []*mockResponse{
+				{
+					ExpectedUri:  "/repos/test-owner/test-repo/environments/production",
+					ResponseBody: `{"message": "Not Found"}`,
+					StatusCode:   404,
+				},
+				putResponse,
+				{
+					ExpectedUri:    "/repos/test-owner/test-repo/environments/production",
+					ExpectedMethod: "DELETE",
+					StatusCode:     204,
+				},
+			},
+			severity: diag.Error,
+		},
+		{
+			// An environment that existed before must not be deleted, its
+			// previous configuration is put back instead.
+			name:   "strict with existing environment",
+			strict: true,
+			responses: []*mockResponse{
+				{
+					ExpectedUri: "/repos/test-owner/test-repo/environments/production",
+					ResponseBody: `{"id": 42, "name": "production", "can_admins_bypass": false, "protection_rules": [
+						{"type": "wait_timer", "wait_timer": 30}
+					]}`,
+					StatusCode: 200,
+				},
+				putResponse,
+				{
+					ExpectedUri:    "/repos/test-owner/test-repo/environments/production",
+					ExpectedMethod: "PUT",
+					ExpectedBody:   []byte(`{"wait_timer":30,"reviewers":null,"can_admins_bypass":false,"deployment_branch_policy":null}` + "\n"),
+					ResponseBody:   `{"id": 42, "name": "production"}`,
+					StatusCode:     200,
+				},
+			},
+			severity: diag.Error,
+		},
+		{
+			name:   "lenient",
+			strict: false,
+			responses: []*mockResponse{
+				putResponse,
+				{
+					ExpectedUri:  "/repos/test-owner/test-repo",
+					ResponseBody: `{"name": "test-repo"}`,
+					StatusCode:   200,
+				},
+				{
+					ExpectedUri:  "/repos/test-owner/test-repo/environments/production",
+					ResponseBody: putResponse.ResponseBody,
+					StatusCode:   200,
+				},
+			},
+			severity: diag.Warning,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			meta := newMockOwner(t, tc.responses)
+
+			d := schema.TestResourceDataRaw(t, resourceGithubRepositoryEnvironment().Schema, map[string]any{
+				"repository":       "test-repo",
+				"environment":      "production",
+				"strict_reviewers": tc.strict,
+				"reviewers": []any{
+					map[string]any{
+						"users": []any{1, 2},
+					},
+				},
+			})
+
+			diags := resourceGithubRepositoryEnvironmentCreate(context.Background(), d, meta)
+			if len(diags) != 1 || diags[0].Severity != tc.severity {
+				t.Fatalf("expected a single diagnostic of severity %v, got %v", tc.severity, diags)
+			}
+			if !strings.Contains(diags[0].Detail, "user 2") || strings.Contains(diags[0].Detail, "user 1") {
+				t.Errorf("expected only user 2 to be reported as dropped: %s", diags[0].Detail)
+			}
+
+			// A strict failure removes or restores the environment, so nothing
+			// is left in state.
+			if tc.strict && d.Id() != "" {
+				t.Errorf("expected the environment to be removed from state, got id %q", d.Id())
+			}
+			if !tc.strict && d.Id() == "" {
+				t.Error("expected the environment to be kept in state")
+			}
+		})
+	}
+}
+
+func TestGithubRepositoryEnvironmentCanAdminsBypassWithoutProtectionRules(t *testing.T) {
+	envResponse := `{"id": 42, "name": "production", "can_admins_bypass": true, "protection_rules": []}`
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:    "/repos/test-owner/test-repo/environments/production",
+			ExpectedMethod: "PUT",
+			ExpectedBody:   []byte(`{"wait_timer":0,"reviewers":[],"can_admins_bypass":false,"deployment_branch_policy":null,"prevent_self_review":false}` + "\n"),
+			ResponseBody:   envResponse,
+			StatusCode:     200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo",
+			ResponseBody: `{"name": "test-repo"}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/environments/production",
+			ResponseBody: envResponse,
+			StatusCode:   200,
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceGithubRepositoryEnvironment().Schema, map[string]any{
+		"repository":        "test-repo",
+		"environment":       "production",
+		"can_admins_bypass": false,
+	})
+
+	diags := resourceGithubRepositoryEnvironmentCreate(context.Background(), d, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if !strings.Contains(diags[0].Detail, "no protection rules") {
+		t.Errorf("expected the warning to explain that the setting is moot: %s", diags[0].Detail)
+	}
+
+	// The value GitHub reports is read back, and the difference to the
+	// configuration is only suppressed while there are no protection rules.
+	if got := d.Get("can_admins_bypass"); got != true {
+		t.Errorf("expected the can_admins_bypass reported by GitHub, got %v", got)
+	}
+}
+
+func TestSuppressCanAdminsBypassWithoutProtectionRules(t *testing.T) {
+	reviewerIDs := cty.Set(cty.Number)
+	reviewers := func(users ...int64) cty.Value {
+		ids := cty.SetValEmpty(cty.Number)
+		if len(users) > 0 {
+			vals := make([]cty.Value, len(users))
+			for i, id := range users {
+				vals[i] = cty.NumberIntVal(id)
+			}
+			ids = cty.SetVal(vals)
+		}
+		return cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"teams": cty.NullVal(reviewerIDs),
+			"users": ids,
+		})})
+	}
+	branchPolicy := func(protected, allBranches bool) cty.Value {
+		return cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"protected_branches":     cty.BoolVal(protected),
+			"custom_branch_policies": cty.BoolVal(false),
+			"all_branches":           cty.BoolVal(allBranches),
+		})})
+	}
+
+	for _, tc := range []struct {
+		name     string
+		config   map[string]cty.Value
+		state    map[string]string
+		suppress bool
+	}{
+		{
+			name:     "without protection rules",
+			config:   map[string]cty.Value{},
+			suppress: true,
+		},
+		{
+			name:     "with a branch policy allowing all branches",
+			config:   map[string]cty.Value{"deployment_branch_policy": branchPolicy(false, true)},
+			suppress: true,
+		},
+		{
+			name:     "with a wait timer",
+			config:   map[string]cty.Value{"wait_timer": cty.NumberIntVal(10)},
+			suppress: false,
+		},
+		{
+			name:     "with an unknown wait timer",
+			config:   map[string]cty.Value{"wait_timer": cty.UnknownVal(cty.Number)},
+			suppress: false,
+		},
+		{
+			name:     "with reviewers",
+			config:   map[string]cty.Value{"reviewers": reviewers(1)},
+			suppress: false,
+		},
+		{
+			name:     "with empty reviewers",
+			config:   map[string]cty.Value{"reviewers": reviewers()},
+			suppress: true,
+		},
+		{
+			name:     "with a protected branches policy",
+			config:   map[string]cty.Value{"deployment_branch_policy": branchPolicy(true, false)},
+			suppress: false,
+		},
+		{
+			// Reviewers added in GitHub only are cleared by the next apply,
+			// leaving nothing to bypass.
+			name:   "with reviewers from GitHub only",
+			config: map[string]cty.Value{},
+			state: map[string]string{
+				"wait_timer":                 "5",
+				"reviewers.#":                "1",
+				"reviewers.0.users.#":        "1",
+				"reviewers.0.users.12345678": "1",
+			},
+			suppress: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			attrs := map[string]string{
+				"repository":  "test-repo",
+				"environment": "production",
+			}
+			for k, v := range tc.state {
+				attrs[k] = v
+			}
+			tc.config["repository"] = cty.StringVal("test-repo")
+			tc.config["environment"] = cty.StringVal("production")
+			d := resourceGithubRepositoryEnvironment().Data(&terraform.InstanceState{
+				ID:         "test-repo:production",
+				Attributes: attrs,
+				RawConfig:  rawEnvironmentConfig(tc.config),
+			})
+
+			if got := suppressCanAdminsBypassWithoutProtectionRules("can_admins_bypass", "true", "false", d); got != tc.suppress {
+				t.Errorf("expected suppress to be %t, got %t", tc.suppress, got)
+			}
+		})
+	}
+}
+
+func TestGithubRepositoryEnvironmentReadDeletedReviewers(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo",
+			ResponseBody: `{"name": "test-repo"}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri: "/repos/test-owner/test-repo/environments/production",
+			ResponseBody: `{"id": 42, "name": "production", "protection_rules": [
+				{"type": "required_reviewers", "reviewers": [
+					{"type": "User", "reviewer": {"id": 1}},
+					{"type": "User", "reviewer": null},
+					{"type": "Team", "reviewer": {"id": 0}}
+				]}
+			]}`,
+			StatusCode: 200,
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceGithubRepositoryEnvironment().Schema, map[string]any{
+		"repository":  "test-repo",
+		"environment": "production",
+	})
+	d.SetId("test-repo:production")
+
+	if diags := resourceGithubRepositoryEnvironmentRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("reviewers.0.users").(*schema.Set); got.Len() != 1 || !got.Contains(1) {
+		t.Errorf("expected only user 1 to be read, got %v", got.List())
+	}
+	if got := d.Get("reviewers.0.teams").(*schema.Set); got.Len() != 0 {
+		t.Errorf("expected no teams to be read, got %v", got.List())
+	}
+}
+
+func TestGithubRepositoryEnvironmentReadForbidden(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		response        *mockResponse
+		expectedSummary string
+	}{
+		{
+			name: "missing app permission",
+			response: &mockResponse{
+				ExpectedUri:  "/repos/test-owner/test-repo/environments/production",
+				ResponseBody: `{"message": "Resource not accessible by integration", "documentation_url": "https://docs.github.com/rest"}`,
+				StatusCode:   403,
+			},
+			expectedSummary: "Missing permission to read repository environment",
+		},
+		{
+			name: "primary rate limit",
+			response: &mockResponse{
+				ExpectedUri:  "/repos/test-owner/test-repo/environments/production",
+				ResponseBody: `{"message": "API rate limit exceeded"}`,
+				ResponseHeaders: map[string]string{
+					"X-RateLimit-Limit":     "5000",
+					"X-RateLimit-Remaining": "0",
+					"X-RateLimit-Reset":     "4102444800",
+				},
+				StatusCode: 403,
+			},
+			expectedSummary: "API rate limit",
+		},
+		{
+			name: "secondary rate limit",
+			response: &mockResponse{
+				ExpectedUri:  "/repos/test-owner/test-repo/environments/production",
+				ResponseBody: `{"message": "You have exceeded a secondary rate limit", "documentation_url": "https://docs.github.com/rest/overview/rate-limits-for-the-rest-api#about-secondary-rate-limits"}`,
+				StatusCode:   403,
+			},
+			expectedSummary: "secondary rate limit",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			meta := newMockOwner(t, []*mockResponse{
+				{
+					ExpectedUri:  "/repos/test-owner/test-repo",
+					ResponseBody: `{"name": "test-repo"}`,
+					StatusCode:   200,
+				},
+				tc.response,
+			})
+
+			d := resourceGithubRepositoryEnvironment().TestResourceData()
+			d.SetId("test-repo:production")
+
+			diags := resourceGithubRepositoryEnvironmentRead(context.Background(), d, meta)
+			if !diags.HasError() || len(diags) != 1 {
+				t.Fatalf("expected a single error, got %v", diags)
+			}
+			if !strings.Contains(diags[0].Summary, tc.expectedSummary) {
+				t.Errorf("expected the error summary to contain %q, got %q", tc.expectedSummary, diags[0].Summary)
+			}
+			if d.Id() == "" {
+				t.Error("expected the environment to stay in state")
+			}
+		})
+	}
+}
+
+func TestGithubRepositoryEnvironmentURL(t *testing.T) {
+	cases := []struct {
+		name     string
+		htmlURL  string
+		expected string
+	}{
+		{
+			name:     "github.com",
+			htmlURL:  "https://github.com/test-owner/test-repo",
+			expected: "https://github.com/test-owner/test-repo/settings/environments/42",
+		},
+		{
+			name:     "GHES",
+			htmlURL:  "https://ghes.example.com/test-owner/test-repo",
+			expected: "https://ghes.example.com/test-owner/test-repo/settings/environments/42",
+		},
+		{
+			name:     "unknown repository URL",
+			htmlURL:  "",
+			expected: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := environmentURL(tc.htmlURL, 42); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestGithubRepositoryEnvironmentAllBranchesPolicy(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceGithubRepositoryEnvironment().Schema, map[string]any{
+		"repository":  "test-repo",
+		"environment": "production",
+		"deployment_branch_policy": []any{
+			map[string]any{
+				"all_branches": true,
+			},
+		},
+	})
+
+	if data := createUpdateEnvironmentData(d, nil); data.DeploymentBranchPolicy != nil {
+		t.Errorf("expected no deployment branch policy for all branches, got %v", data.DeploymentBranchPolicy)
+	}
+
+	err := validateEnvironmentBranchPolicy(map[string]any{
+		"all_branches":           true,
+		"protected_branches":     false,
+		"custom_branch_policies": true,
+	})
+	if err == nil {
+		t.Error("expected all_branches combined with custom_branch_policies to be rejected")
+	}
+}
+
+func TestGithubRepositoryEnvironmentEmptyReviewers(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		reviewers []any
+	}{
+		{name: "empty list", reviewers: []any{}},
+		{name: "empty block", reviewers: []any{nil}},
+		{name: "empty sets", reviewers: []any{map[string]any{"teams": []any{}, "users": []any{}}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceGithubRepositoryEnvironment().Schema, map[string]any{
+				"repository":  "test-repo",
+				"environment": "production",
+				"reviewers":   tc.reviewers,
+			})
+
+			if data := createUpdateEnvironmentData(d, nil); len(data.Reviewers) != 0 {
+				t.Errorf("expected no reviewers, got %v", data.Reviewers)
+			}
+		})
+	}
+
+	if got := expandReviewers([]any{}, "teams"); len(got) != 0 {
+		t.Errorf("expected no team reviewers from an empty list, got %v", got)
+	}
+}
+
+func TestGithubRepositoryEnvironmentReviewersPayload(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		reviewers map[string]any
+		expected  string
+	}{
+		{name: "users only", reviewers: map[string]any{"users": []any{2, 1}}, expected: "User/1,User/2"},
+		{name: "teams only", reviewers: map[string]any{"teams": []any{5}}, expected: "Team/5"},
+		{name: "both", reviewers: map[string]any{"teams": []any{5}, "users": []any{1}}, expected: "Team/5,User/1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceGithubRepositoryEnvironment().Schema, map[string]any{
+				"repository":  "test-repo",
+				"environment": "production",
+				"reviewers":   []any{tc.reviewers},
+			})
+
+			got := make([]string, 0)
+			for _, r := range createUpdateEnvironmentData(d, nil).Reviewers {
+				got = append(got, fmt.Sprintf("%s/%d", r.GetType(), r.GetID()))
+			}
+			if strings.Join(got, ",") != tc.expected {
+				t.Errorf("expected reviewers %s, got %s", tc.expected, strings.Join(got, ","))
+			}
+		})
+	}
+
+	// A reviewers block built outside of the schema may lack a key entirely
+	// instead of holding an empty set.
+	if got := expandReviewers([]any{map[string]any{"users": schema.NewSet(schema.HashInt, []any{1})}}, "teams"); len(got) != 0 {
+		t.Errorf("expected no team reviewers when the teams key is absent, got %v", got)
+	}
+}
+
+func TestValidateEnvironmentPreventSelfReview(t *testing.T) {
+	reviewerType := cty.Object(map[string]cty.Type{"teams": cty.Set(cty.Number), "users": cty.Set(cty.Number)})
+
+	for _, tc := range []struct {
+		name              string
+		preventSelfReview cty.Value
+		reviewers         cty.Value
+		warn              bool
+	}{
+		{
+			name:              "without reviewers",
+			preventSelfReview: cty.True,
+			reviewers:         cty.NullVal(cty.List(reviewerType)),
+			warn:              true,
+		},
+		{
+			name:              "with empty reviewers",
+			preventSelfReview: cty.True,
+			reviewers: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"teams": cty.NullVal(cty.Set(cty.Number)),
+				"users": cty.SetValEmpty(cty.Number),
+			})}),
+			warn: true,
+		},
+		{
+			name:              "with reviewers",
+			preventSelfReview: cty.True,
+			reviewers: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"teams": cty.NullVal(cty.Set(cty.Number)),
+				"users": cty.SetVal([]cty.Value{cty.NumberIntVal(1)}),
+			})}),
+		},
+		{
+			name:              "with unknown reviewers",
+			preventSelfReview: cty.True,
+			reviewers:         cty.UnknownVal(cty.List(reviewerType)),
+		},
+		{
+			name:              "disabled",
+			preventSelfReview: cty.False,
+			reviewers:         cty.NullVal(cty.List(reviewerType)),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := schema.ValidateResourceConfigFuncRequest{
+				RawConfig: cty.ObjectVal(map[string]cty.Value{
+					"prevent_self_review": tc.preventSelfReview,
+					"reviewers":           tc.reviewers,
+				}),
+			}
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+
+			validateEnvironmentPreventSelfReview(context.Background(), req, resp)
+
+			if tc.warn && (len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Severity != diag.Warning) {
+				t.Errorf("expected a single warning, got %v", resp.Diagnostics)
+			}
+			if !tc.warn && len(resp.Diagnostics) != 0 {
+				t.Errorf("expected no diagnostics, got %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestBuildEnvironmentReviewers(t *testing.T) {
+	reviewers := buildEnvironmentReviewers([]int64{3, 1, 3}, []int64{3, 2, 2})
+
+	expected := []string{"Team/1", "Team/3", "User/2", "User/3"}
+	got := make([]string, 0, len(reviewers))
+	for _, r := range reviewers {
+		got = append(got, fmt.Sprintf("%s/%d", r.GetType(), r.GetID()))
+	}
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected reviewers %v, got %v", expected, got)
+	}
+}
+
+func TestGithubRepositoryEnvironmentEmptyDeploymentBranchPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		policy []any
+	}{
+		{name: "empty list", policy: []any{}},
+		{name: "unset", policy: nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceGithubRepositoryEnvironment().Schema, map[string]any{
+				"repository":               "test-repo",
+				"environment":              "production",
+				"deployment_branch_policy": tc.policy,
+			})
+
+			if data := createUpdateEnvironmentData(d, nil); data.DeploymentBranchPolicy != nil {
+				t.Errorf("expected no deployment branch policy, got %v", data.DeploymentBranchPolicy)
+			}
+		})
+	}
+
+	for _, v := range []any{nil, []any{}, []any{nil}} {
+		if _, ok := expandDeploymentBranchPolicy(v); ok {
+			t.Errorf("expected no deployment branch policy from %#v", v)
+		}
+	}
+}
+
+func TestGithubRepositoryEnvironmentDefaults(t *testing.T) {
+	defaults := &EnvironmentDefaults{
+		WaitTimer:       github.Ptr(30),
+		CanAdminsBypass: github.Ptr(false),
+	}
+
+	t.Run("applies defaults to omitted settings", func(t *testing.T) {
+		d := environmentResourceDataWithRawConfig(t, map[string]cty.Value{
+			"repository":  cty.StringVal("test-repo"),
+			"environment": cty.StringVal("production"),
+		})
+
+		data := createUpdateEnvironmentData(d, defaults)
+		if data.GetWaitTimer() != 30 {
+			t.Errorf("expected the default wait timer of 30, got %v", data.WaitTimer)
+		}
+		if data.GetCanAdminsBypass() {
+			t.Error("expected the default can_admins_bypass of false")
+		}
+		if data.GetPreventSelfReview() {
+			t.Error("expected prevent_self_review without a default to keep the resource default")
+		}
+	})
+
+	t.Run("resource settings take precedence", func(t *testing.T) {
+		d := environmentResourceDataWithRawConfig(t, map[string]cty.Value{
+			"repository":        cty.StringVal("test-repo"),
+			"environment":       cty.StringVal("production"),
+			"wait_timer":        cty.NumberIntVal(0),
+			"can_admins_bypass": cty.True,
+		})
+
+		data := createUpdateEnvironmentData(d, defaults)
+		if data.GetWaitTimer() != 0 {
+			t.Errorf("expected the explicit wait timer of 0, got %v", data.GetWaitTimer())
+		}
+		if !data.GetCanAdminsBypass() {
+			t.Error("expected the explicit can_admins_bypass of true")
+		}
+	})
+
+	t.Run("keeps defaulted settings omitted in state", func(t *testing.T) {
+		meta := newMockOwner(t, []*mockResponse{
+			{
+				ExpectedUri:  "/repos/test-owner/test-repo",
+				ResponseBody: `{"name": "test-repo"}`,
+				StatusCode:   200,
+			},
+			{
+				ExpectedUri: "/repos/test-owner/test-repo/environments/production",
+				ResponseBody: `{"name": "production", "can_admins_bypass": false, "protection_rules": [
+					{"type": "wait_timer", "wait_timer": 30}
+				]}`,
+				StatusCode: 200,
+			},
+		})
+		meta.environmentDefaults = defaults
+
+		d := schema.TestResourceDataRaw(t, resourceGithubRepositoryEnvironment().Schema, map[string]any{
+			"repository":  "test-repo",
+			"environment": "production",
+		})
+		d.SetId("test-repo:production")
+		_ = d.Set("defaulted_settings", []any{"wait_timer", "can_admins_bypass"})
+
+		if diags := resourceGithubRepositoryEnvironmentRead(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if got := d.Get("wait_timer"); got != 0 {
+			t.Errorf("expected the defaulted wait_timer to stay omitted, got %v", got)
+		}
+		if got := d.Get("can_admins_bypass"); got != true {
+			t.Errorf("expected the defaulted can_admins_bypass to keep the resource default, got %v", got)
+		}
+	})
+
+	t.Run("reports drift of explicit settings that match the defaults", func(t *testing.T) {
+		meta := newMockOwner(t, []*mockResponse{
+			{
+				ExpectedUri:  "/repos/test-owner/test-repo",
+				ResponseBody: `{"name": "test-repo"}`,
+				StatusCode:   200,
+			},
+			{
+				ExpectedUri: "/repos/test-owner/test-repo/environments/production",
+				ResponseBody: `{"name": "production", "can_admins_bypass": false, "protection_rules": [
+					{"type": "wait_timer", "wait_timer": 30}
+				]}`,
+				StatusCode: 200,
+			},
+		})
+		meta.environmentDefaults = defaults
+
+		// wait_timer = 0 and can_admins_bypass = true are set explicitly, so
+		// nothing is recorded in defaulted_settings.
+		d := schema.TestResourceDataRaw(t, resourceGithubRepositoryEnvironment().Schema, map[string]any{
+			"repository":        "test-repo",
+			"environment":       "production",
+			"wait_timer":        0,
+			"can_admins_bypass": true,
+		})
+		d.SetId("test-repo:production")
+
+		if diags := resourceGithubRepositoryEnvironmentRead(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if got := d.Get("wait_timer"); got != 30 {
+			t.Errorf("expected the drifted wait_timer of 30, got %v", got)
+		}
+		if got := d.Get("can_admins_bypass"); got != false {
+			t.Errorf("expected the drifted can_admins_bypass of false, got %v", got)
+		}
+	})
+
+	t.Run("records defaulted settings", func(t *testing.T) {
+		d := environmentResourceDataWithRawConfig(t, map[string]cty.Value{
+			"repository":        cty.StringVal("test-repo"),
+			"environment":       cty.StringVal("production"),
+			"can_admins_bypass": cty.True,
+		})
+
+		got := environmentDefaultedSettings(d, defaults)
+		if !slices.Equal(got, []string{"wait_timer"}) {
+			t.Errorf("expected only wait_timer to be defaulted, got %v", got)
+		}
+	})
+}
+
+// environmentResourceDataWithRawConfig builds resource data whose raw config
+// only holds the given attributes, which schema.TestResourceDataRaw does not
+// populate.
+func environmentResourceDataWithRawConfig(t *testing.T, config map[string]cty.Value) *schema.ResourceData {
+	t.Helper()
+
+	state := &terraform.InstanceState{Attributes: map[string]string{}}
+	for name, v := range config {
+		switch {
+		case v.Type() == cty.Number:
+			state.Attributes[name] = v.AsBigFloat().String()
+		case v.Type() == cty.Bool:
+			state.Attributes[name] = strconv.FormatBool(v.True())
+		default:
+			state.Attributes[name] = v.AsString()
+		}
+	}
+	state.RawConfig = rawEnvironmentConfig(config)
+
+	return resourceGithubRepositoryEnvironment().Data(state)
+}
+
+// rawEnvironmentConfig builds the raw configuration of an environment, with
+// every attribute missing from config set to null.
+func rawEnvironmentConfig(config map[string]cty.Value) cty.Value {
+	attrs := make(map[string]cty.Value)
+	for name, ty := range resourceGithubRepositoryEnvironment().CoreConfigSchema().ImpliedType().AttributeTypes() {
+		attrs[name] = cty.NullVal(ty)
+	}
+	for name, v := range config {
+		attrs[name] = v
+	}
+	return cty.ObjectVal(attrs)
+}
+
+func TestGithubRepositoryEnvironmentImportMissingEnvironment(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:  "/repos/test-owner/test-repo/environments/missing",
+			ResponseBody: `{"message": "Not Found"}`,
+			StatusCode:   404,
+		},
+	})
+
+	d := resourceGithubRepositoryEnvironment().TestResourceData()
+	d.SetId("test-repo:missing")
+
+	_, err := resourceGithubRepositoryEnvironmentImport(context.Background(), d, meta)
+	if err == nil {
+		t.Fatal("expected an error importing a missing environment")
+	}
+
+	expected := "environment missing does not exist in repository test-repo; nothing to import"
+	if err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err.Error())
+	}
+}
+
+func TestGithubRepositoryEnvironmentImportOwnerQualifiedID(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		id   string
+	}{
+		{name: "repository and environment", id: "test-repo:production"},
+		{name: "owner qualified", id: "test-owner/test-repo:production"},
+		{name: "owner qualified with different case", id: "Test-Owner/test-repo:production"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			meta := newMockOwner(t, []*mockResponse{
+				{
+					ExpectedUri:  "/repos/test-owner/test-repo/environments/production",
+					ResponseBody: `{"name": "production"}`,
+					StatusCode:   200,
+				},
+			})
+
+			d := resourceGithubRepositoryEnvironment().TestResourceData()
+			d.SetId(tc.id)
+
+			if _, err := resourceGithubRepositoryEnvironmentImport(context.Background(), d, meta); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if d.Id() != "test-repo:production" {
+				t.Errorf("expected id test-repo:production, got %s", d.Id())
+			}
+			if got := d.Get("repository"); got != "test-repo" {
+				t.Errorf("expected repository test-repo, got %v", got)
+			}
+		})
+	}
+
+	t.Run("other owner", func(t *testing.T) {
+		meta := &Owner{name: "test-owner"}
+
+		d := resourceGithubRepositoryEnvironment().TestResourceData()
+		d.SetId("other-owner/test-repo:production")
+
+		_, err := resourceGithubRepositoryEnvironmentImport(context.Background(), d, meta)
+		if err == nil || !strings.Contains(err.Error(), "belongs to other-owner") {
+			t.Errorf("expected an error about the owner mismatch, got %v", err)
+		}
+	})
+}
+
+func TestValidateEnvironmentCustomBranchPolicies(t *testing.T) {
+	res := resourceGithubRepositoryEnvironment()
+	policyType := res.CoreConfigSchema().ImpliedType().AttributeType("deployment_branch_policy")
+
+	policy := func(custom cty.Value) cty.Value {
+		attrs := make(map[string]cty.Value)
+		for name, ty := range policyType.ElementType().AttributeTypes() {
+			attrs[name] = cty.NullVal(ty)
+		}
+		attrs["custom_branch_policies"] = custom
+		return cty.ListVal([]cty.Value{cty.ObjectVal(attrs)})
+	}
+
+	for _, tc := range []struct {
+		name   string
+		policy cty.Value
+		warn   bool
+	}{
+		{name: "custom branch policies", policy: policy(cty.True), warn: true},
+		{name: "protected branches", policy: policy(cty.False)},
+		{name: "unknown custom branch policies", policy: policy(cty.UnknownVal(cty.Bool))},
+		{name: "without a policy", policy: cty.ListValEmpty(policyType.ElementType())},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// The raw config of a new environment, which is validated on the
+			// plan that creates it just as on later updates.
+			attrs := make(map[string]cty.Value)
+			for name, ty := range res.CoreConfigSchema().ImpliedType().AttributeTypes() {
+				attrs[name] = cty.NullVal(ty)
+			}
+			attrs["repository"] = cty.StringVal("test-repo")
+			attrs["environment"] = cty.StringVal("production")
+			attrs["deployment_branch_policy"] = tc.policy
+
+			req := schema.ValidateResourceConfigFuncRequest{RawConfig: cty.ObjectVal(attrs)}
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			for _, f := range res.ValidateRawResourceConfigFuncs {
+				f(context.Background(), req, resp)
+			}
+
+			if tc.warn && (len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Severity != diag.Warning ||
+				!strings.Contains(resp.Diagnostics[0].Detail, "github_repository_environment_deployment_policy")) {
+				t.Errorf("expected a single warning about deployment policies, got %v", resp.Diagnostics)
+			}
+			if !tc.warn && len(resp.Diagnostics) != 0 {
+				t.Errorf("expected no diagnostics, got %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestGithubRepositoryEnvironmentUpdateClearsReviewers(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config map[string]any
+	}{
+		{
+			name: "removed block",
+			config: map[string]any{
+				"repository":  "test-repo",
+				"environment": "production",
+			},
+		},
+		{
+			name: "empty block",
+			config: map[string]any{
+				"repository":  "test-repo",
+				"environment": "production",
+				"reviewers":   []any{nil},
+			},
+		},
+		{
+			// A dynamic block without elements.
+			name: "empty list",
+			config: map[string]any{
+				"repository":  "test-repo",
+				"environment": "production",
+				"reviewers":   []any{},
+			},
+		},
+		{
+			name: "empty sets",
+			config: map[string]any{
+				"repository":  "test-repo",
+				"environment": "production",
+				"reviewers":   []any{map[string]any{"teams": []any{}, "users": []any{}}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			meta := newMockOwner(t, []*mockResponse{
+				{
+					ExpectedUri:  "/repos/test-owner/test-repo",
+					ResponseBody: `{"name": "test-repo"}`,
+					StatusCode:   200,
+				},
+				{
+					ExpectedUri:    "/repos/test-owner/test-repo/environments/production",
+					ExpectedMethod: "PUT",
+					ExpectedBody:   []byte(`{"wait_timer":0,"reviewers":[],"can_admins_bypass":true,"deployment_branch_policy":null,"prevent_self_review":false}` + "\n"),
+					ResponseBody:   `{"id": 42, "name": "production", "can_admins_bypass": true}`,
+					StatusCode:     200,
+				},
+			})
+
+			d := schema.TestResourceDataRaw(t, resourceGithubRepositoryEnvironment().Schema, tc.config)
+			d.SetId("test-repo:production")
+
+			if diags := resourceGithubRepositoryEnvironmentUpdate(context.Background(), d, meta); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+		})
+	}
+}
+
+func TestGithubRepositoryEnvironmentDryRun(t *testing.T) {
+	// No request is expected, any call exhausts the mock and fails with a 400.
+	meta := newMockOwner(t, []*mockResponse{})
+	meta.dryRun = true
+
+	d := schema.TestResourceDataRaw(t, resourceGithubRepositoryEnvironment().Schema, map[string]any{
+		"repository":  "test-repo",
+		"environment": "production",
+		"wait_timer":  10,
+	})
+
+	if diags := resourceGithubRepositoryEnvironmentCreate(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error on create: %v", diags)
+	}
+	if got := d.Id(); got != "test-repo:production" {
+		t.Errorf("expected id test-repo:production, got %s", got)
+	}
+	if got := d.Get("wait_timer"); got != 10 {
+		t.Errorf("expected the planned wait_timer 10 in state, got %v", got)
+	}
+
+	if diags := resourceGithubRepositoryEnvironmentUpdate(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error on update: %v", diags)
+	}
+
+	if diags := resourceGithubRepositoryEnvironmentDelete(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error on delete: %v", diags)
+	}
+}
+
+func TestGithubRepositoryEnvironmentCreateRejectedWaitTimer(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:    "/repos/test-owner/test-repo/environments/production",
+			ExpectedMethod: "PUT",
+			ResponseBody:   `{"message": "Failed to create the wait timer protection rule for this environment."}`,
+			StatusCode:     422,
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceGithubRepositoryEnvironment().Schema, map[string]any{
+		"repository":  "test-repo",
+		"environment": "production",
+		"wait_timer":  43200,
+	})
+
+	diags := resourceGithubRepositoryEnvironmentCreate(context.Background(), d, meta)
+	if len(diags) != 1 || diags[0].Severity != diag.Error {
+		t.Fatalf("expected a single error, got %v", diags)
+	}
+	if diags[0].Summary != "GitHub rejected the wait timer of the environment" {
+		t.Errorf("unexpected error summary: %s", diags[0].Summary)
+	}
+	if !strings.Contains(diags[0].Detail, "wait_timer of 43200 minutes for environment production of repository test-repo") {
+		t.Errorf("unexpected error detail: %s", diags[0].Detail)
+	}
+	if !diags[0].AttributePath.Equals(cty.GetAttrPath("wait_timer")) {
+		t.Errorf("expected the error to point at wait_timer, got %#v", diags[0].AttributePath)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected no id, got %s", d.Id())
+	}
+}
+
+func TestValidateEnvironmentWaitTimer(t *testing.T) {
+	for _, tc := range []struct {
+		waitTimer    int
+		maxWaitTimer int
+		expectError  bool
+	}{
+		{waitTimer: 43200, maxWaitTimer: 0, expectError: false},
+		{waitTimer: 60, maxWaitTimer: 60, expectError: false},
+		{waitTimer: 61, maxWaitTimer: 60, expectError: true},
+	} {
+		err := validateEnvironmentWaitTimer(tc.waitTimer, tc.maxWaitTimer)
+		if (err != nil) != tc.expectError {
+			t.Errorf("wait_timer %d with max_wait_timer %d: expected error %t, got %v", tc.waitTimer, tc.maxWaitTimer, tc.expectError, err)
+		}
+	}
+}
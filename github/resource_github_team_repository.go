@@ -54,6 +54,35 @@ func resourceGithubTeamRepository() *schema.Resource {
 				Default:     "pull",
 				Description: "The permissions of team members regarding the repository. Must be one of 'pull', 'triage', 'push', 'maintain', 'admin' or the name of an existing custom repository role within the organisation.",
 			},
+			"permissions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The effective capabilities that the permission grants team members on the repository.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"admin": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"maintain": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"push": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"triage": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"pull": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
 			"etag": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -83,14 +112,28 @@ func resourceGithubTeamRepositoryCreate(d *schema.ResourceData, meta any) error
 	permission := d.Get("permission").(string)
 	ctx := context.Background()
 
+	options := &github.TeamAddTeamRepoOptions{
+		Permission: permission,
+	}
+	if skipForDryRun(meta.(*Owner), fmt.Sprintf("adding repository %s/%s to team %d", orgName, repoName, teamId), options) {
+		d.SetId(buildTwoPartID(strconv.FormatInt(teamId, 10), repoName))
+		return nil
+	}
+
+	if _, _, err := client.Repositories.Get(ctx, orgName, repoName); err != nil {
+		var ghErr *github.ErrorResponse
+		if errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("repository %s not found in organization %s", repoName, orgName)
+		}
+		return err
+	}
+
 	_, err = client.Teams.AddTeamRepoByID(ctx,
 		orgId,
 		teamId,
 		orgName,
 		repoName,
-		&github.TeamAddTeamRepoOptions{
-			Permission: permission,
-		},
+		options,
 	)
 	if err != nil {
 		return err
@@ -177,10 +220,25 @@ if repo.GetArchived() {
 	if err = d.Set("permission", getPermission(repo.GetRoleName())); err != nil {
 		return err
 	}
+	if err = d.Set("permissions", flattenTeamRepositoryPermissions(repo.GetPermissions())); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+func flattenTeamRepositoryPermissions(permissions *github.RepositoryPermissions) []any {
+	return []any{
+		map[string]any{
+			"admin":    permissions.GetAdmin(),
+			"maintain": permissions.GetMaintain(),
+			"push":     permissions.GetPush(),
+			"triage":   permissions.GetTriage(),
+			"pull":     permissions.GetPull(),
+		},
+	}
+}
+
 func resourceGithubTeamRepositoryUpdate(d *schema.ResourceData, meta any) error {
 	err := checkOrganization(meta)
 	if err != nil {
@@ -202,6 +260,13 @@ func resourceGithubTeamRepositoryUpdate(d *schema.ResourceData, meta any) error
 	permission := d.Get("permission").(string)
 	ctx := context.WithValue(context.Background(), ctxId, d.Id())
 
+	options := &github.TeamAddTeamRepoOptions{
+		Permission: permission,
+	}
+	if skipForDryRun(meta.(*Owner), fmt.Sprintf("updating repository %s/%s of team %d", orgName, repoName, teamId), options) {
+		return nil
+	}
+
 //---------- manual insert start ----------
 // 1️⃣ Check if repository exists
 repo, _, repoErr := client.Repositories.Get(ctx, orgName, repoName)
@@ -229,9 +294,7 @@ if repo.GetArchived() {
 		teamId,
 		orgName,
 		repoName,
-		&github.TeamAddTeamRepoOptions{
-			Permission: permission,
-		},
+		options,
 	)
 	if err != nil {
 		return err
@@ -263,7 +326,9 @@ func resourceGithubTeamRepositoryDelete(d *schema.ResourceData, meta any) error
 	orgName := meta.(*Owner).name
 	ctx := context.WithValue(context.Background(), ctxId, d.Id())
 
-	
+	if skipForDryRun(meta.(*Owner), fmt.Sprintf("removing repository %s/%s from team %d", orgName, repoName, teamId), nil) {
+		return nil
+	}
 
 	//---------- manual insert start ----------
 	repo, _, repoErr := client.Repositories.Get(ctx, orgName, repoName)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestAccGithubTeamRepository(t *testing.T) {
@@ -146,6 +147,94 @@ func TestAccGithubTeamRepository(t *testing.T) {
 	})
 }
 
+func TestAccGithubTeamRepositoryImport(t *testing.T) {
+	t.Run("imports a built-in role without a diff", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		teamName := fmt.Sprintf("%steam-repo-import-%s", testResourcePrefix, randomID)
+		repoName := fmt.Sprintf("%srepo-team-repo-import-%s", testResourcePrefix, randomID)
+
+		config := fmt.Sprintf(`
+			resource "github_team" "test" {
+				name        = "%s"
+				description = "test"
+			}
+
+			resource "github_repository" "test" {
+				name = "%s"
+			}
+
+			resource "github_team_repository" "test" {
+				team_id    = github_team.test.id
+				repository = github_repository.test.name
+				permission = "maintain"
+			}
+		`, teamName, repoName)
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnlessHasOrgs(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+				},
+				{
+					ResourceName:      "github_team_repository.test",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+	})
+
+	t.Run("imports a custom role without a diff", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		teamName := fmt.Sprintf("%steam-repo-import-role-%s", testResourcePrefix, randomID)
+		repoName := fmt.Sprintf("%srepo-team-repo-import-role-%s", testResourcePrefix, randomID)
+
+		config := fmt.Sprintf(`
+			resource "github_organization_custom_role" "test" {
+				name        = "tf-acc-test-%s"
+				description = "test"
+				base_role   = "read"
+				permissions = ["reopen_issue"]
+			}
+
+			resource "github_team" "test" {
+				name        = "%s"
+				description = "test"
+			}
+
+			resource "github_repository" "test" {
+				name = "%s"
+			}
+
+			resource "github_team_repository" "test" {
+				team_id    = github_team.test.id
+				repository = github_repository.test.name
+				permission = github_organization_custom_role.test.name
+			}
+		`, randomID, teamName, repoName)
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { skipUnlessHasPaidOrgs(t) },
+			ProviderFactories: providerFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("github_team_repository.test", "permission", fmt.Sprintf("tf-acc-test-%s", randomID)),
+					),
+				},
+				{
+					ResourceName:      "github_team_repository.test",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+	})
+}
+
 func TestAccGithubTeamRepositoryArchivedRepo(t *testing.T) {
 	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
 	teamName := fmt.Sprintf("%steam-archive-%s", testResourcePrefix, randomID)
@@ -229,3 +318,106 @@ func TestAccGithubTeamRepositoryArchivedRepo(t *testing.T) {
 		})
 	})
 }
+
+func TestGithubTeamRepositoryCreateMissingRepository(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:  "/repos/test-org/missing-repo",
+			ResponseBody: `{"message": "Not Found"}`,
+			StatusCode:   404,
+		},
+	})
+	meta.name = "test-org"
+	meta.id = 1
+	meta.IsOrganization = true
+
+	d := schema.TestResourceDataRaw(t, resourceGithubTeamRepository().Schema, map[string]any{
+		"team_id":    "123",
+		"repository": "missing-repo",
+	})
+
+	err := resourceGithubTeamRepositoryCreate(d, meta)
+	if err == nil {
+		t.Fatal("expected an error for a missing repository")
+	}
+
+	expected := "repository missing-repo not found in organization test-org"
+	if err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err.Error())
+	}
+}
+
+func TestGithubTeamRepositoryReadPermissions(t *testing.T) {
+	meta := newMockOwner(t, []*mockResponse{
+		{
+			ExpectedUri:  "/repos/test-org/test-repo",
+			ResponseBody: `{"name": "test-repo"}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri: "/organizations/1/team/123/repos/test-org/test-repo",
+			ResponseBody: `{"name": "test-repo", "role_name": "write", "permissions": {
+				"admin": false, "maintain": false, "push": true, "triage": true, "pull": true
+			}}`,
+			StatusCode: 200,
+		},
+	})
+	meta.name = "test-org"
+	meta.id = 1
+	meta.IsOrganization = true
+
+	d := schema.TestResourceDataRaw(t, resourceGithubTeamRepository().Schema, map[string]any{
+		"team_id":    "123",
+		"repository": "test-repo",
+	})
+	d.SetId(buildTwoPartID("123", "test-repo"))
+
+	if err := resourceGithubTeamRepositoryRead(d, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := d.Get("permission"); got != "push" {
+		t.Errorf("expected permission push, got %v", got)
+	}
+	for key, expected := range map[string]bool{
+		"admin":    false,
+		"maintain": false,
+		"push":     true,
+		"triage":   true,
+		"pull":     true,
+	} {
+		if got := d.Get("permissions.0." + key); got != expected {
+			t.Errorf("expected permissions.0.%s to be %t, got %v", key, expected, got)
+		}
+	}
+}
+
+func TestGithubTeamRepositoryDryRun(t *testing.T) {
+	// No request is expected, any call exhausts the mock and fails with a 400.
+	meta := newMockOwner(t, []*mockResponse{})
+	meta.name = "test-org"
+	meta.id = 1
+	meta.IsOrganization = true
+	meta.dryRun = true
+
+	d := schema.TestResourceDataRaw(t, resourceGithubTeamRepository().Schema, map[string]any{
+		"team_id":    "123",
+		"repository": "test-repo",
+		"permission": "push",
+	})
+
+	if err := resourceGithubTeamRepositoryCreate(d, meta); err != nil {
+		t.Fatalf("unexpected error on create: %s", err)
+	}
+	if got := d.Id(); got != "123:test-repo" {
+		t.Errorf("expected id 123:test-repo, got %s", got)
+	}
+
+	if err := resourceGithubTeamRepositoryUpdate(d, meta); err != nil {
+		t.Fatalf("unexpected error on update: %s", err)
+	}
+
+	if err := resourceGithubTeamRepositoryDelete(d, meta); err != nil {
+		t.Fatalf("unexpected error on delete: %s", err)
+	}
+}
@@ -54,11 +54,24 @@ type RateLimitTransport struct {
 	writeDelay       time.Duration
 	readDelay        time.Duration
 	parallelRequests bool
+	budget           *requestBudget
 
 	m sync.Mutex
 }
 
 func (rlt *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Wait for the shared request budget, if any, before anything else so that
+	// parallel requests queue up on it too.
+	if rlt.budget != nil {
+		if wait := rlt.budget.reserve(); wait > 0 {
+			log.Printf("[DEBUG] Request budget exhausted, waiting %s before sending the request", wait)
+			sleep(req.Context(), wait)
+			if err := req.Context().Err(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Make requests for a single user or client ID serially when parallel_requests is false.
 	// If parallel_requests is true skips the lock and allow the parallelism defined by terraform itself.
 	rlt.smartLock(true)
@@ -189,6 +202,57 @@ func WithParallelRequests(p bool) RateLimitTransportOption {
 	}
 }
 
+// WithRequestsPerHour is used to cap the number of requests sent per hour.
+// Zero or less leaves the request rate unbounded.
+func WithRequestsPerHour(n int) RateLimitTransportOption {
+	return func(rlt *RateLimitTransport) {
+		if n > 0 {
+			rlt.budget = newRequestBudget(n)
+		}
+	}
+}
+
+// requestBudget is a token bucket shared by every request of the provider. It
+// refills continuously at the configured hourly rate and holds at most a
+// minute's worth of requests, so that short bursts are allowed but a long
+// apply is spread out instead of running into GitHub's rate limits.
+type requestBudget struct {
+	interval time.Duration
+	burst    float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newRequestBudget(requestsPerHour int) *requestBudget {
+	burst := float64(max(1, requestsPerHour/60))
+	return &requestBudget{
+		interval: time.Hour / time.Duration(requestsPerHour),
+		burst:    burst,
+		tokens:   burst,
+		last:     time.Now(),
+	}
+}
+
+// reserve takes one request from the budget and returns how long the caller
+// has to wait before sending it. The budget may go negative, in which case
+// later callers queue behind the ones already waiting.
+func (b *requestBudget) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+float64(now.Sub(b.last))/float64(b.interval))
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens * float64(b.interval))
+}
+
 // drainBody reads all of b to memory and then returns two equivalent
 // ReadClosers yielding the same bytes.
 func drainBody(b io.ReadCloser) (r1, r2 io.ReadCloser, err error) {
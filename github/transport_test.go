@@ -47,6 +47,22 @@ func TestEtagTransport(t *testing.T) {
 	}
 }
 
+// newMockOwner returns an owner named test-owner whose REST client is served
+// by githubApiMock, which is closed when the test finishes.
+func newMockOwner(t *testing.T, responseSequence []*mockResponse) *Owner {
+	ts := githubApiMock(responseSequence)
+	t.Cleanup(ts.Close)
+
+	client := github.NewClient(&http.Client{})
+	u, _ := url.Parse(ts.URL + "/")
+	client.BaseURL = u
+
+	return &Owner{
+		name:     "test-owner",
+		v3client: client,
+	}
+}
+
 func githubApiMock(responseSequence []*mockResponse) *httptest.Server {
 	position := github.Ptr(0)
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -196,6 +212,42 @@ func TestRateLimitTransport_abuseLimit_get_cancelled(t *testing.T) {
 	}
 }
 
+func TestRateLimitTransport_requestBudget_exhausted(t *testing.T) {
+	responses := make([]*mockResponse, 0, 3)
+	for range 3 {
+		responses = append(responses, &mockResponse{
+			ExpectedUri:  "/repos/test/blah",
+			ResponseBody: `{"id": 1234}`,
+			StatusCode:   200,
+		})
+	}
+	ts := githubApiMock(responses)
+	defer ts.Close()
+
+	// 36000 requests per hour refill the budget every 100ms.
+	rlt := NewRateLimitTransport(http.DefaultTransport, WithRequestsPerHour(36000))
+	rlt.budget.tokens = 0
+
+	client := github.NewClient(&http.Client{Transport: rlt})
+	u, _ := url.Parse(ts.URL + "/")
+	client.BaseURL = u
+
+	start := time.Now()
+	for i := range 3 {
+		r, _, err := client.Repositories.Get(t.Context(), "test", "blah")
+		if err != nil {
+			t.Fatalf("Expected request %d to wait for the budget instead of failing, got: %v", i, err)
+		}
+		if r.GetID() != 1234 {
+			t.Fatalf("Expected ID to be 1234, got: %d", r.GetID())
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 250*time.Millisecond {
+		t.Fatalf("Expected requests to queue on the exhausted budget, finished after %s", elapsed)
+	}
+}
+
 func TestRateLimitTransport_abuseLimit_post(t *testing.T) {
 	ts := githubApiMock([]*mockResponse{
 		{
@@ -3,6 +3,8 @@ package github
 import (
 	"context"
 	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -17,6 +19,7 @@ import (
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shurcooL/githubv4"
 )
 
 const (
@@ -37,6 +40,17 @@ func unescapeIDPart(part string) string {
 	return strings.ReplaceAll(part, idSeparatorEscaped, idSeparator)
 }
 
+// unescapeIDPartFromState unescapes an ID part, preferring the value already
+// recorded under key when it escapes to the same part. The escaping is not
+// reversible for values containing idSeparatorEscaped themselves, so the
+// stored value is the only way to recover them.
+func unescapeIDPartFromState(d *schema.ResourceData, key, part string) string {
+	if v, ok := d.Get(key).(string); ok && v != "" && escapeIDPart(v) == part {
+		return v
+	}
+	return unescapeIDPart(part)
+}
+
 // buildID joins the parts with the idSeparator.
 func buildID(parts ...string) (string, error) {
 	l := len(parts)
@@ -248,12 +262,17 @@ func splitRepoFilePath(path string) (string, string) {
 }
 
 func getTeamID(teamIDString string, meta any) (int64, error) {
-	// Given a string that is either a team id or team slug, return the
-	// id of the team it is referring to.
+	// Given a string that is either a team id, team node id or team slug,
+	// return the id of the team it is referring to.
 	ctx := context.Background()
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 
+	if isTeamNodeID(teamIDString) {
+		teamId, _, err := getTeamFromNodeID(ctx, teamIDString, meta)
+		return teamId, err
+	}
+
 	teamId, parseIntErr := strconv.ParseInt(teamIDString, 10, 64)
 	if parseIntErr == nil {
 		return teamId, nil
@@ -273,12 +292,17 @@ func getTeamSlug(teamIDString string, meta any) (string, error) {
 }
 
 func getTeamSlugContext(ctx context.Context, teamIDString string, meta any) (string, error) {
-	// Given a string that is either a team id or team slug, return the
-	// team slug it is referring to.
+	// Given a string that is either a team id, team node id or team slug,
+	// return the team slug it is referring to.
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 	orgId := meta.(*Owner).id
 
+	if isTeamNodeID(teamIDString) {
+		_, slug, err := getTeamFromNodeID(ctx, teamIDString, meta)
+		return slug, err
+	}
+
 	teamId, parseIntErr := strconv.ParseInt(teamIDString, 10, 64)
 	if parseIntErr != nil {
 		// The given id not an integer, assume it is a team slug
@@ -302,6 +326,49 @@ func getTeamSlugContext(ctx context.Context, teamIDString string, meta any) (str
 	return team.GetSlug(), nil
 }
 
+var legacyTeamNodeIDRegexp = regexp.MustCompile(`^\d+:Team\d+$`)
+
+// isTeamNodeID reports whether the given string is a GraphQL node ID of a
+// team, either in the current format (T_kwDO...) or the legacy base64 encoded
+// one (MDQ6VGVhbTE=). Team slugs are always lowercase, so neither format can
+// be mistaken for one.
+func isTeamNodeID(s string) bool {
+	if strings.HasPrefix(s, "T_") {
+		return true
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return false
+	}
+	return legacyTeamNodeIDRegexp.Match(decoded)
+}
+
+// getTeamFromNodeID resolves the GraphQL node ID of a team to the numeric ID
+// and slug used by the REST API.
+func getTeamFromNodeID(ctx context.Context, nodeID string, meta any) (int64, string, error) {
+	var query struct {
+		Node struct {
+			Team struct {
+				DatabaseID githubv4.Int
+				Slug       githubv4.String
+			} `graphql:"... on Team"`
+		} `graphql:"node(id: $id)"`
+	}
+	variables := map[string]any{
+		"id": githubv4.ID(nodeID),
+	}
+
+	if err := meta.(*Owner).v4client.Query(ctx, &query, variables); err != nil {
+		return -1, "", err
+	}
+	if query.Node.Team.DatabaseID == 0 {
+		return -1, "", fmt.Errorf("node %s is not a team", nodeID)
+	}
+
+	return int64(query.Node.Team.DatabaseID), string(query.Node.Team.Slug), nil
+}
+
 // https://docs.github.com/en/actions/reference/encrypted-secrets#naming-your-secrets
 var secretNameRegexp = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
 
@@ -382,3 +449,24 @@ func lookupTeamID(ctx context.Context, meta *Owner, slug string) (int64, error)
 	}
 	return team.GetID(), nil
 }
+
+// skipForDryRun reports whether the provider is configured for a dry run, in
+// which case it logs the API call described by operation and payload and the
+// caller must not make it.
+func skipForDryRun(meta *Owner, operation string, payload any) bool {
+	if !meta.dryRun {
+		return false
+	}
+
+	if payload == nil {
+		log.Printf("[INFO] Dry run, skipping %s", operation)
+		return true
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		body = fmt.Appendf(nil, "%+v", payload)
+	}
+	log.Printf("[INFO] Dry run, skipping %s: %s", operation, body)
+	return true
+}
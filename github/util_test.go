@@ -1,10 +1,14 @@
 package github
 
 import (
+	"net/http"
+	"net/url"
 	"testing"
 	"unicode"
 
+	"github.com/google/go-github/v82/github"
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/shurcooL/githubv4"
 )
 
 func Test_escapeIDPart(t *testing.T) {
@@ -450,6 +454,152 @@ func TestGithubUtilTwoPartID(t *testing.T) {
 	}
 }
 
+func TestGithubUtilIDRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, d := range []struct {
+		testName    string
+		repository  string
+		environment string
+	}{
+		{
+			testName:    "plain",
+			repository:  "repo",
+			environment: "production",
+		},
+		{
+			testName:    "environment_with_separator",
+			repository:  "repo",
+			environment: "prod:eu",
+		},
+		{
+			testName:    "environment_with_multiple_separators",
+			repository:  "repo.with-dots_and-dashes",
+			environment: "prod:eu:west",
+		},
+		{
+			testName:    "environment_with_spaces_and_slashes",
+			repository:  "repo",
+			environment: "environment / test",
+		},
+	} {
+		t.Run(d.testName, func(t *testing.T) {
+			t.Parallel()
+
+			id, err := buildID(d.repository, escapeIDPart(d.environment))
+			if err != nil {
+				t.Fatalf("unexpected error building id: %s", err)
+			}
+
+			repository, environmentPart, err := parseID2(id)
+			if err != nil {
+				t.Fatalf("unexpected error parsing id %q: %s", id, err)
+			}
+
+			if repository != d.repository {
+				t.Errorf("expected repository %q but got %q", d.repository, repository)
+			}
+			if environment := unescapeIDPart(environmentPart); environment != d.environment {
+				t.Errorf("expected environment %q but got %q", d.environment, environment)
+			}
+		})
+	}
+}
+
+func TestGithubUtilIDRejectsSeparatorInNonFinalPart(t *testing.T) {
+	if _, err := buildID("repo:name", "environment"); err == nil {
+		t.Fatal("expected an unescaped separator in the repository part to be rejected")
+	}
+}
+
+func TestGithubUtilUnescapeIDPartFromState(t *testing.T) {
+	t.Parallel()
+
+	for _, d := range []struct {
+		testName string
+		state    string
+		part     string
+		expected string
+	}{
+		{
+			testName: "import_without_state",
+			state:    "",
+			part:     "prod??eu",
+			expected: "prod:eu",
+		},
+		{
+			testName: "state_with_separator",
+			state:    "prod:eu",
+			part:     "prod??eu",
+			expected: "prod:eu",
+		},
+		{
+			// "what??" escapes to itself, so unescaping alone would turn it
+			// into "what:".
+			testName: "state_with_literal_escape_sequence",
+			state:    "what??",
+			part:     "what??",
+			expected: "what??",
+		},
+		{
+			testName: "state_not_matching_id",
+			state:    "staging",
+			part:     "prod??eu",
+			expected: "prod:eu",
+		},
+	} {
+		t.Run(d.testName, func(t *testing.T) {
+			t.Parallel()
+
+			rd := resourceGithubRepositoryEnvironment().TestResourceData()
+			if d.state != "" {
+				if err := rd.Set("environment", d.state); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if got := unescapeIDPartFromState(rd, "environment", d.part); got != d.expected {
+				t.Fatalf("expected %q but got %q", d.expected, got)
+			}
+		})
+	}
+}
+
+func TestGithubUtilTwoPartIDRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, d := range []struct {
+		testName string
+		left     string
+		right    string
+	}{
+		{
+			testName: "team_id_and_repository",
+			left:     "1234",
+			right:    "repo",
+		},
+		{
+			// Only the left part must be free of the separator, the right
+			// part is kept whole.
+			testName: "right_part_with_separator",
+			left:     "1234",
+			right:    "user:name",
+		},
+	} {
+		t.Run(d.testName, func(t *testing.T) {
+			t.Parallel()
+
+			left, right, err := parseTwoPartID(buildTwoPartID(d.left, d.right), "team_id", "repository")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if left != d.left || right != d.right {
+				t.Fatalf("expected %q and %q but got %q and %q", d.left, d.right, left, right)
+			}
+		})
+	}
+}
+
 func flipUsernameCase(username string) string {
 	oc := []rune(username)
 
@@ -515,3 +665,110 @@ func TestGithubUtilValidateSecretName(t *testing.T) {
 		}
 	}
 }
+
+func TestGetTeamID(t *testing.T) {
+	for _, d := range []struct {
+		testName  string
+		input     string
+		responses []*mockResponse
+	}{
+		{
+			testName: "numeric_id",
+			input:    "1234",
+		},
+		{
+			testName: "slug",
+			input:    "my-team",
+			responses: []*mockResponse{
+				{
+					ExpectedUri:  "/orgs/test-org/teams/my-team",
+					ResponseBody: `{"id": 1234, "slug": "my-team"}`,
+					StatusCode:   200,
+				},
+			},
+		},
+		{
+			testName: "node_id",
+			input:    "T_kwDOBhkMZs4AW1Jx",
+			responses: []*mockResponse{
+				{
+					ExpectedUri:    "/graphql",
+					ExpectedMethod: "POST",
+					ResponseBody:   `{"data": {"node": {"databaseId": 1234, "slug": "my-team"}}}`,
+					StatusCode:     200,
+				},
+			},
+		},
+		{
+			testName: "legacy_node_id",
+			input:    "MDQ6VGVhbTEyMzQ=",
+			responses: []*mockResponse{
+				{
+					ExpectedUri:    "/graphql",
+					ExpectedMethod: "POST",
+					ResponseBody:   `{"data": {"node": {"databaseId": 1234, "slug": "my-team"}}}`,
+					StatusCode:     200,
+				},
+			},
+		},
+	} {
+		t.Run(d.testName, func(t *testing.T) {
+			ts := githubApiMock(d.responses)
+			defer ts.Close()
+
+			client := github.NewClient(&http.Client{})
+			u, _ := url.Parse(ts.URL + "/")
+			client.BaseURL = u
+
+			meta := &Owner{
+				name:     "test-org",
+				v3client: client,
+				v4client: githubv4.NewEnterpriseClient(ts.URL+"/graphql", &http.Client{}),
+			}
+
+			got, err := getTeamID(d.input, meta)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != 1234 {
+				t.Errorf("expected team ID 1234, got %d", got)
+			}
+		})
+	}
+}
+
+func TestGetTeamIDRejectsNonTeamNodeID(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/graphql",
+			ExpectedMethod: "POST",
+			ResponseBody:   `{"data": {"node": {}}}`,
+			StatusCode:     200,
+		},
+	})
+	defer ts.Close()
+
+	meta := &Owner{
+		name:     "test-org",
+		v4client: githubv4.NewEnterpriseClient(ts.URL+"/graphql", &http.Client{}),
+	}
+
+	if _, err := getTeamID("T_kwDOBhkMZs4AW1Jx", meta); err == nil {
+		t.Error("expected an error for a node that is not a team")
+	}
+}
+
+func Test_isTeamNodeID(t *testing.T) {
+	for input, expected := range map[string]bool{
+		"T_kwDOBhkMZs4AW1Jx":   true,
+		"MDQ6VGVhbTEyMzQ=":     true,
+		"1234":                 false,
+		"my-team":              false,
+		"team_a":               false,
+		"MDEwOlJlcG9zaXRvcnkx": false,
+	} {
+		if got := isTeamNodeID(input); got != expected {
+			t.Errorf("isTeamNodeID(%q) = %t, expected %t", input, got, expected)
+		}
+	}
+}